@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/config"
+	repositorycatalog "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/catalog"
+	repositorygames "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/games"
+	repositorylibrary "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/library"
+	servicecatalog "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/catalog"
+	servicegames "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/games"
+	servicelibrary "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/library"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+)
+
+// main - импорт игр в комнату из выгрузки BoardGameGeek, Steam или файла CSV/JSON.
+//
+//	go run ./cmd/import -room <room_id> -format bgg -file collection.xml
+func main() {
+	configPath := flag.String("config", "config/config.yaml", "путь к файлу конфигурации")
+	roomID := flag.String("room", "", "ID комнаты")
+	userID := flag.String("user", "", "ID пользователя, от имени которого игры заносятся в каталог (необязательно)")
+	format := flag.String("format", "", "формат файла: bgg, steam, csv или json")
+	path := flag.String("file", "", "путь к файлу выгрузки")
+	flag.Parse()
+
+	logger.InitLogger("")
+	ctx := context.Background()
+
+	if *roomID == "" || *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadConfig(ctx, *configPath)
+	if err != nil {
+		logger.Fatalf(ctx, "failed to load config: %v", err)
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		logger.Fatalf(ctx, "failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	games, err := servicegames.Parse(*format, file)
+	if err != nil {
+		logRowErrors(ctx, err)
+		logger.Fatalf(ctx, "failed to parse file: %v", err)
+	}
+
+	db, err := sqlx.Open("postgres", cfg.Database.GetDBUrl())
+	if err != nil {
+		logger.Fatalf(ctx, "failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	// Сервер не запущен, поэтому события комнате не рассылаются:
+	// участники увидят игры после обновления списка.
+	catalogService := servicecatalog.NewService(repositorycatalog.NewRepository(db.DB))
+	libraryService := servicelibrary.NewService(repositorylibrary.NewRepository(db.DB), catalogService)
+	gameService := servicegames.NewService(repositorygames.NewRepository(db.DB), catalogService, libraryService)
+
+	res, err := gameService.Import(ctx, *roomID, *userID, games)
+	for _, skipped := range res.Skipped {
+		logger.Infof(ctx, "row %d %q skipped: %s", skipped.Row, skipped.Title, skipped.Reason)
+	}
+	if err != nil {
+		logRowErrors(ctx, err)
+		logger.Fatalf(ctx, "import failed, no games added: %v", err)
+	}
+
+	logger.Infof(ctx, "Imported %d games, skipped %d", len(res.Added), len(res.Skipped))
+}
+
+// logRowErrors выводит ошибки отдельных игр файла, если они есть.
+func logRowErrors(ctx context.Context, err error) {
+	var importErr *servicegames.ImportError
+	if !errors.As(err, &importErr) {
+		return
+	}
+	for _, row := range importErr.Rows {
+		logger.Errorf(ctx, "row %d %q: %s", row.Row, row.Title, row.Error)
+	}
+}
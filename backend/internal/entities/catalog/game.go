@@ -0,0 +1,28 @@
+package catalog
+
+import "time"
+
+// Game - игра общего каталога. Игры комнат ссылаются на неё через catalog_id и хранят
+// своё описание: при добавлении из каталога оно копируется, а потом меняется в комнате.
+type Game struct {
+	ID              string    `json:"id"`
+	Title           string    `json:"title"`
+	MinPlayers      int       `json:"min_players"`
+	MaxPlayers      int       `json:"max_players"`
+	DurationMinutes int       `json:"duration_minutes"`
+	Tags            []string  `json:"tags"`
+	Link            string    `json:"link"`
+	CreatedBy       string    `json:"created_by"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// Stats - голоса и выборы игры каталога, собранные по всем комнатам.
+// Votes - сумма очков одобрений, Picks - число неотменённых результатов с этой игрой.
+// Нулевое LastPickedAt - игру ещё не выбирали.
+type Stats struct {
+	Rooms        int       `json:"rooms"`
+	Votes        int       `json:"votes"`
+	Vetoes       int       `json:"vetoes"`
+	Picks        int       `json:"picks"`
+	LastPickedAt time.Time `json:"last_picked_at"`
+}
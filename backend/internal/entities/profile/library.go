@@ -0,0 +1,43 @@
+package profile
+
+import (
+	"time"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+// LibraryGame - игра из личной библиотеки пользователя. Описание копируется
+// в игру комнаты, когда владелец добавляет её в комнату.
+type LibraryGame struct {
+	ID              string    `json:"id"`
+	UserID          string    `json:"user_id"`
+	CatalogID       string    `json:"catalog_id"`
+	Title           string    `json:"title"`
+	MinPlayers      int       `json:"min_players"`
+	MaxPlayers      int       `json:"max_players"`
+	DurationMinutes int       `json:"duration_minutes"`
+	Tags            []string  `json:"tags"`
+	Link            string    `json:"link"`
+	Notes           string    `json:"notes"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// Game возвращает описание игры библиотеки как игру комнаты без ID и комнаты.
+func (g LibraryGame) Game() rooms.Game {
+	return rooms.Game{
+		Title:           g.Title,
+		MinPlayers:      g.MinPlayers,
+		MaxPlayers:      g.MaxPlayers,
+		DurationMinutes: g.DurationMinutes,
+		Tags:            g.Tags,
+		Link:            g.Link,
+		Notes:           g.Notes,
+		CatalogID:       g.CatalogID,
+	}
+}
+
+// GameOwners - участники комнаты, у которых игра комнаты есть в библиотеке.
+type GameOwners struct {
+	GameID string `json:"game_id"`
+	Owners []User `json:"owners"`
+}
@@ -0,0 +1,50 @@
+package rooms
+
+import "time"
+
+// Статусы турнирной сетки.
+const (
+	BracketStatusActive    = "active"
+	BracketStatusFinished  = "finished"
+	BracketStatusCancelled = "cancelled"
+)
+
+// Статусы матча: ожидает своей очереди, открыт для голосования, завершён.
+const (
+	MatchStatusPending = "pending"
+	MatchStatusOpen    = "open"
+	MatchStatusClosed  = "closed"
+)
+
+// Bracket - турнир на выбывание между играми комнаты.
+type Bracket struct {
+	ID           string         `json:"id"`
+	RoomID       string         `json:"room_id"`
+	Status       string         `json:"status"`
+	MatchSeconds int            `json:"match_seconds"`
+	WinnerGameID string         `json:"winner_game_id,omitempty"`
+	CreatedBy    string         `json:"created_by"`
+	Matches      []BracketMatch `json:"matches"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// BracketMatch - матч двух игр. Пустой GameB означает, что GameA проходит дальше без матча.
+// Votes - число голосов за каждую из игр матча.
+type BracketMatch struct {
+	ID           string         `json:"id"`
+	BracketID    string         `json:"bracket_id"`
+	Round        int            `json:"round"`
+	Position     int            `json:"position"`
+	GameA        string         `json:"game_a"`
+	GameB        string         `json:"game_b,omitempty"`
+	Status       string         `json:"status"`
+	WinnerGameID string         `json:"winner_game_id,omitempty"`
+	Votes        map[string]int `json:"votes"`
+	OpenedAt     time.Time      `json:"opened_at"`
+	ClosesAt     time.Time      `json:"closes_at"`
+}
+
+// HasGame проверяет, что игра участвует в матче.
+func (m BracketMatch) HasGame(gameID string) bool {
+	return gameID != "" && (gameID == m.GameA || gameID == m.GameB)
+}
@@ -1,30 +1,211 @@
 package rooms
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+)
 
+// Game - игра комнаты. MinPlayers, MaxPlayers и DurationMinutes равны 0, если не указаны.
+// CatalogID - игра общего каталога, к которой относится игра комнаты; её описание
+// в комнате может отличаться от каталожного. BroughtBy - участник, который приносит игру
+// (пустой, если не указан). ArchivedAt - когда игра удалена из комнаты (нулевое время,
+// если игра не в архиве); архивные игры остаются только в истории выборов.
 type Game struct {
-	ID        string    `json:"id"`
-	RoomID    string    `json:"room_id"`
-	Title     string    `json:"title"`
-	CreatedAt time.Time `json:"created_at"`
+	ID              string    `json:"id"`
+	RoomID          string    `json:"room_id"`
+	Title           string    `json:"title"`
+	MinPlayers      int       `json:"min_players"`
+	MaxPlayers      int       `json:"max_players"`
+	DurationMinutes int       `json:"duration_minutes"`
+	Tags            []string  `json:"tags"`
+	Link            string    `json:"link"`
+	Notes           string    `json:"notes"`
+	CatalogID       string    `json:"catalog_id"`
+	BroughtBy       string    `json:"brought_by"`
+	ArchivedAt      time.Time `json:"archived_at"`
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 func (g Game) IsValid() bool {
 	return g.ID != "" && g.RoomID != "" && g.Title != ""
 }
 
+func (g Game) IsArchived() bool {
+	return !g.ArchivedAt.IsZero()
+}
+
+// Ограничения описания игры.
+const (
+	MaxGameTags        = 20
+	MaxGameTagLength   = 32
+	MaxGameNotesLength = 2000
+)
+
+// NormalizeTags приводит метки к нижнему регистру без пробелов по краям,
+// убирает пустые метки и повторы, сохраняя порядок.
+func NormalizeTags(tags []string) []string {
+	res := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(res, tag) {
+			res = append(res, tag)
+		}
+	}
+	return res
+}
+
+// Виды голосов: одобрение повышает шансы игры, вето исключает её из выбора.
+const (
+	VoteKindApprove = "approve"
+	VoteKindVeto    = "veto"
+)
+
 type Vote struct {
 	ID        string    `json:"id"`
 	RoomID    string    `json:"room_id"`
 	GameID    string    `json:"game_id"`
 	UserID    string    `json:"user_id"`
+	Kind      string    `json:"kind"`
+	Points    int       `json:"points"`
+	PollID    string    `json:"poll_id"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
-type Result struct {
+func (v Vote) IsValid() bool {
+	return v.ID != "" && v.RoomID != "" && v.GameID != "" && v.UserID != "" &&
+		(v.Kind == VoteKindApprove || v.Kind == VoteKindVeto)
+}
+
+// Ballot - ранжированный бюллетень участника: игры в порядке убывания предпочтения.
+type Ballot struct {
 	ID        string    `json:"id"`
 	RoomID    string    `json:"room_id"`
-	GameID    string    `json:"game_id"`
-	ChosenBy  string    `json:"chosen_by"`
+	UserID    string    `json:"user_id"`
+	Rankings  []string  `json:"rankings"`
+	PollID    string    `json:"poll_id"`
 	CreatedAt time.Time `json:"created_at"`
 }
+
+// Result - сохранённый результат выбора. LandsAt - момент остановки колеса, до которого
+// результат не раскрывается. Options - ограничения розыгрыша, с которыми пройдёт перевыбор
+// результата; в ответах они не отдаются. GameTitle и GameArchived заполняются
+// только в истории выборов, чтобы её можно было показать и для архивных игр.
+type Result struct {
+	ID         string       `json:"id"`
+	RoomID     string       `json:"room_id"`
+	GameID     string       `json:"game_id"`
+	ChosenBy   string       `json:"chosen_by"`
+	Strategy   string       `json:"strategy"`
+	Seed       string       `json:"seed,omitempty"`
+	Commitment string       `json:"commitment,omitempty"`
+	Snapshot   DrawSnapshot `json:"snapshot"`
+	Options    PickOptions  `json:"-"`
+	BatchID    string       `json:"batch_id"`
+	Position   int          `json:"position"`
+	PollID     string       `json:"poll_id"`
+	RerollOf   string       `json:"reroll_of,omitempty"`
+	Reroll     *Reroll      `json:"reroll,omitempty"`
+	LandsAt    time.Time    `json:"lands_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+
+	GameTitle    string `json:"game_title,omitempty"`
+	GameArchived bool   `json:"game_archived,omitempty"`
+}
+
+// Reroll - отмена результата перевыбором: кто, когда и почему перевыбрал игру.
+type Reroll struct {
+	By     string    `json:"by"`
+	Reason string    `json:"reason"`
+	At     time.Time `json:"at"`
+}
+
+// Lineup - подборка игр, выбранных за один розыгрыш, в порядке выпадения.
+// RerollOf - результат, вместо которого подборка выбрана перевыбором.
+type Lineup struct {
+	BatchID   string       `json:"batch_id"`
+	PollID    string       `json:"poll_id"`
+	RerollOf  string       `json:"reroll_of,omitempty"`
+	ChosenBy  string       `json:"chosen_by"`
+	Strategy  string       `json:"strategy"`
+	Games     []LineupGame `json:"games"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// LineupGame - игра подборки: ResultID - сохранённый результат, Position - место в подборке.
+// Title - название игры, Archived - игра с тех пор удалена из комнаты.
+// Reroll заполняется, если результат отменён перевыбором.
+type LineupGame struct {
+	ResultID string  `json:"result_id"`
+	Position int     `json:"position"`
+	GameID   string  `json:"game_id"`
+	Title    string  `json:"title"`
+	Archived bool    `json:"archived"`
+	Reroll   *Reroll `json:"reroll,omitempty"`
+}
+
+// WeightedCandidate - игра и вес, с которым она участвовала в розыгрыше.
+type WeightedCandidate struct {
+	GameID string  `json:"game_id"`
+	Weight float64 `json:"weight"`
+}
+
+// DrawSnapshot - входные данные розыгрыша, по которым его можно повторить, зная зерно.
+// Ballots заполняется только для ранжированного голосования.
+type DrawSnapshot struct {
+	Candidates []WeightedCandidate `json:"candidates"`
+	Ballots    [][]string          `json:"ballots,omitempty"`
+}
+
+// PickOptions - ограничения, с которыми выбран результат: пропуск cooldown, состав, длительность,
+// метки и, если выбор шёл только из игр присутствующих, участники Available на момент выбора.
+type PickOptions struct {
+	IgnoreCooldown bool     `json:"ignore_cooldown,omitempty"`
+	Players        int      `json:"players,omitempty"`
+	MaxMinutes     int      `json:"max_minutes,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	ExcludeTags    []string `json:"exclude_tags,omitempty"`
+	AvailableOnly  bool     `json:"available_only,omitempty"`
+	Available      []string `json:"available,omitempty"`
+}
+
+// Candidate - игра-кандидат для выбора: Votes - сумма очков одобрений, Vetoes - число вето,
+// CooledDown - игра недавно выпадала и по настройкам комнаты пропускает розыгрыш.
+// Score - очки одобрений, по которым стратегии считают веса: в режиме справедливости
+// очки каждого участника умножаются на его множитель, иначе Score равен Votes.
+// MinPlayers, MaxPlayers, DurationMinutes, Tags и BroughtBy - описание игры для ограничений розыгрыша.
+type Candidate struct {
+	GameID          string   `json:"game_id"`
+	Votes           int64    `json:"votes"`
+	Vetoes          int64    `json:"vetoes"`
+	CooledDown      bool     `json:"cooled_down"`
+	Score           float64  `json:"score"`
+	MinPlayers      int      `json:"min_players"`
+	MaxPlayers      int      `json:"max_players"`
+	DurationMinutes int      `json:"duration_minutes"`
+	Tags            []string `json:"tags"`
+	BroughtBy       string   `json:"brought_by"`
+}
+
+// Approval - очки одобрений участника за игру в раунде.
+type Approval struct {
+	UserID string
+	GameID string
+	Points int64
+}
+
+// Fairness - множитель голосов участника в режиме справедливости.
+// Wins - сколько из последних выбранных игр комнаты участник поддержал в своём раунде.
+type Fairness struct {
+	UserID     string  `json:"user_id"`
+	Wins       int     `json:"wins"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// VoteTally - итог голосования по одной игре.
+type VoteTally struct {
+	GameID string `json:"game_id"`
+	Voters int    `json:"voters"`
+	Points int    `json:"points"`
+	Vetoes int    `json:"vetoes"`
+}
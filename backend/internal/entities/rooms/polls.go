@@ -0,0 +1,50 @@
+package rooms
+
+import (
+	"slices"
+	"time"
+)
+
+// Статусы раунда голосования: открыт для голосов, закрыт, по раунду выбрана игра.
+const (
+	PollStatusOpen   = "open"
+	PollStatusClosed = "closed"
+	PollStatusPicked = "picked"
+)
+
+// Poll - раунд голосования в комнате. Голоса и результаты выбора относятся к раунду,
+// новый раунд начинается без голосов прошлого.
+type Poll struct {
+	ID        string    `json:"id"`
+	RoomID    string    `json:"room_id"`
+	Status    string    `json:"status"`
+	OpenedBy  string    `json:"opened_by"`
+	CreatedAt time.Time `json:"created_at"`
+	ClosedAt  time.Time `json:"closed_at"`
+	// Deadline - срок голосования, после которого сервер сам выбирает игру. Нулевое время - без срока.
+	Deadline time.Time `json:"deadline"`
+	// RunoffOf - раунд, ничью в котором решает этот дополнительный раунд.
+	// Candidates - игры, разделившие первое место; голосовать можно только за них.
+	RunoffOf   string   `json:"runoff_of,omitempty"`
+	Candidates []string `json:"candidates,omitempty"`
+}
+
+// IsOpen проверяет, что в раунде можно голосовать.
+func (p Poll) IsOpen() bool {
+	return p.Status == PollStatusOpen
+}
+
+// IsRunoff проверяет, что раунд - дополнительный раунд при ничьей.
+func (p Poll) IsRunoff() bool {
+	return p.RunoffOf != ""
+}
+
+// Allows проверяет, что за игру можно голосовать в раунде.
+func (p Poll) Allows(gameID string) bool {
+	return !p.IsRunoff() || slices.Contains(p.Candidates, gameID)
+}
+
+// Expired проверяет, что срок голосования открытого раунда наступил.
+func (p Poll) Expired(now time.Time) bool {
+	return p.IsOpen() && !p.Deadline.IsZero() && !p.Deadline.After(now)
+}
@@ -2,25 +2,87 @@ package rooms
 
 import "time"
 
+// Стратегии выбора игры.
+const (
+	PickStrategyWeighted  = "weighted"
+	PickStrategyPlurality = "plurality"
+	PickStrategyUniform   = "uniform"
+	PickStrategyVotedOnly = "voted_only"
+	PickStrategyRanked    = "ranked"
+)
+
+// IsValidPickStrategy проверяет, что стратегия выбора известна.
+func IsValidPickStrategy(strategy string) bool {
+	switch strategy {
+	case PickStrategyWeighted, PickStrategyPlurality, PickStrategyUniform, PickStrategyVotedOnly, PickStrategyRanked:
+		return true
+	}
+	return false
+}
+
 type Room struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	OwnerID   string    `json:"owner_id"`
-	CreatedAt time.Time `json:"created_at"`
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	OwnerID         string    `json:"owner_id"`
+	PickStrategy    string    `json:"pick_strategy"`
+	VetoLimit       int       `json:"veto_limit"`
+	VoteBudget      int       `json:"vote_budget"`
+	QuadraticVoting bool      `json:"quadratic_voting"`
+	CooldownResults int       `json:"cooldown_results"`
+	CooldownDays    int       `json:"cooldown_days"`
+	AutoPick        bool      `json:"auto_pick"`
+	ReadyQuorum     int       `json:"ready_quorum"`
+	RunoffSeconds   int       `json:"runoff_seconds"`
+	RerollLimit     int       `json:"reroll_limit"`
+	Fairness        bool      `json:"fairness"`
+	AvailableOnly   bool      `json:"available_only"`
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 func (r Room) IsValid() bool {
 	return r.ID != "" && r.Name != "" && r.OwnerID != ""
 }
 
+// VoteCost возвращает стоимость голоса в очках бюджета: points или points² в квадратичном режиме.
+func (r Room) VoteCost(points int) int {
+	if r.QuadraticVoting {
+		return points * points
+	}
+	return points
+}
+
 type RoomParticipant struct {
 	ID        string    `json:"id"`
 	RoomID    string    `json:"room_id"`
 	UserID    string    `json:"user_id"`
 	Role      string    `json:"role"`
+	Ready     bool      `json:"ready"`
+	Present   bool      `json:"present"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
 func (rp RoomParticipant) IsValid() bool {
 	return rp.ID != "" && rp.RoomID != "" && rp.UserID != "" && (rp.Role == "member" || rp.Role == "owner")
 }
+
+// Presence - кто из участников комнаты на месте. Present - отмеченные присутствующими,
+// Connected - подключённые к комнате по WebSocket, Available - участники из любого из списков.
+type Presence struct {
+	Present   []string `json:"present"`
+	Connected []string `json:"connected"`
+	Available []string `json:"available"`
+}
+
+// Readiness - готовность участников комнаты к выбору игры.
+// UserIDs - готовые участники, Quorum - сколько готовых нужно для автоматического выбора.
+type Readiness struct {
+	UserIDs []string `json:"user_ids"`
+	Ready   int      `json:"ready"`
+	Total   int      `json:"total"`
+	Quorum  int      `json:"quorum"`
+}
+
+// Reached проверяет, что готовых участников достаточно для автоматического выбора.
+func (r Readiness) Reached() bool {
+	return r.Total > 0 && r.Ready >= r.Quorum
+}
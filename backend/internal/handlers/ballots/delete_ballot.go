@@ -0,0 +1,38 @@
+package ballots
+
+import (
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/ballots"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type DeleteBallotHandler struct {
+	ballotService ballots.BallotService
+}
+
+func NewDeleteBallotHandler(ballotService ballots.BallotService) *DeleteBallotHandler {
+	return &DeleteBallotHandler{ballotService: ballotService}
+}
+
+func (h *DeleteBallotHandler) Handle(c *fiber.Ctx) error {
+	roomID := c.Locals("room_id").(string)
+	userID := c.Locals("user_id").(string)
+	err := h.ballotService.Delete(c.Context(), roomID, userID)
+	if errors.Is(err, ballots.ErrVotingClosed) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Voting in the current poll is closed"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "DeleteBallot Handle Delete error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to delete ballot"},
+		)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
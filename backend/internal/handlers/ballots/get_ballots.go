@@ -0,0 +1,59 @@
+package ballots
+
+import (
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/ballots"
+	servicepolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/polls"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GetBallotsHandler struct {
+	ballotService ballots.BallotService
+	pollService   servicepolls.PollService
+}
+
+func NewGetBallotsHandler(ballotService ballots.BallotService, pollService servicepolls.PollService) *GetBallotsHandler {
+	return &GetBallotsHandler{ballotService: ballotService, pollService: pollService}
+}
+
+func (h *GetBallotsHandler) Handle(c *fiber.Ctx) error {
+	roomID := c.Locals("room_id").(string)
+
+	// По умолчанию возвращаются бюллетени текущего раунда, poll_id выбирает прошлый раунд.
+	var (
+		poll rooms.Poll
+		err  error
+	)
+	if pollID := c.Query("poll_id"); pollID != "" {
+		poll, err = h.pollService.Get(c.Context(), roomID, pollID)
+	} else {
+		poll, err = h.pollService.Current(c.Context(), roomID)
+	}
+	if errors.Is(err, servicepolls.ErrPollNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Poll not found"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "GetBallots Handle GetPoll error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get poll"},
+		)
+	}
+
+	ballotsList, err := h.ballotService.GetForPoll(c.Context(), poll.ID)
+	if err != nil {
+		logger.Errorf(c.Context(), "GetBallots Handle GetForPoll error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get ballots"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(ballotsList)
+}
@@ -0,0 +1,65 @@
+package ballots
+
+import (
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/ballots"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SubmitBallotHandler struct {
+	ballotService ballots.BallotService
+}
+
+func NewSubmitBallotHandler(ballotService ballots.BallotService) *SubmitBallotHandler {
+	return &SubmitBallotHandler{ballotService: ballotService}
+}
+
+type SubmitBallotRequest struct {
+	Rankings []string `json:"rankings"`
+}
+
+func (h *SubmitBallotHandler) Handle(c *fiber.Ctx) error {
+	roomID := c.Locals("room_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	var req SubmitBallotRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Errorf(c.Context(), "SubmitBallot Handle BodyParser error: %v", err)
+
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Invalid request body"},
+		)
+	}
+
+	ballot, err := h.ballotService.Submit(c.Context(), rooms.Ballot{
+		ID:       uuid.New().String(),
+		RoomID:   roomID,
+		UserID:   userID,
+		Rankings: req.Rankings,
+	})
+	if errors.Is(err, ballots.ErrInvalidBallot) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Rankings must list distinct games of this room"},
+		)
+	}
+
+	if errors.Is(err, ballots.ErrVotingClosed) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Voting in the current poll is closed"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "SubmitBallot Handle Submit error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to submit ballot"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(ballot)
+}
@@ -0,0 +1,58 @@
+package brackets
+
+import (
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/brackets"
+	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CancelBracketHandler struct {
+	bracketService brackets.BracketService
+	roomService    servicerooms.RoomService
+}
+
+func NewCancelBracketHandler(bracketService brackets.BracketService, roomService servicerooms.RoomService) *CancelBracketHandler {
+	return &CancelBracketHandler{bracketService: bracketService, roomService: roomService}
+}
+
+func (h *CancelBracketHandler) Handle(c *fiber.Ctx) error {
+	roomID := c.Locals("room_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	room, err := h.roomService.GetByID(c.Context(), roomID)
+	if err != nil {
+		logger.Errorf(c.Context(), "CancelBracket Handle GetByID error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get room"},
+		)
+	}
+
+	if room.OwnerID != userID {
+		logger.Errorf(c.Context(), "CancelBracket Handle unauthorized user: %v", userID)
+
+		return c.Status(fiber.StatusForbidden).JSON(
+			fiber.Map{"error": "You are not the owner of this room"},
+		)
+	}
+
+	err = h.bracketService.Cancel(c.Context(), roomID)
+	if errors.Is(err, brackets.ErrNoActiveBracket) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "No active bracket in this room"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "CancelBracket Handle Cancel error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to cancel bracket"},
+		)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
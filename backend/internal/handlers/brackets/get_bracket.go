@@ -0,0 +1,36 @@
+package brackets
+
+import (
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/brackets"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GetBracketHandler struct {
+	bracketService brackets.BracketService
+}
+
+func NewGetBracketHandler(bracketService brackets.BracketService) *GetBracketHandler {
+	return &GetBracketHandler{bracketService: bracketService}
+}
+
+func (h *GetBracketHandler) Handle(c *fiber.Ctx) error {
+	roomID := c.Locals("room_id").(string)
+
+	bracket, err := h.bracketService.Get(c.Context(), roomID)
+	if err != nil {
+		logger.Errorf(c.Context(), "GetBracket Handle Get error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get bracket"},
+		)
+	}
+
+	if bracket.ID == "" {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Bracket not found"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(bracket)
+}
@@ -0,0 +1,89 @@
+package brackets
+
+import (
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/brackets"
+	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultMatchSeconds - длительность матча, если она не указана в запросе.
+const defaultMatchSeconds = 60
+
+type StartBracketHandler struct {
+	bracketService brackets.BracketService
+	roomService    servicerooms.RoomService
+}
+
+func NewStartBracketHandler(bracketService brackets.BracketService, roomService servicerooms.RoomService) *StartBracketHandler {
+	return &StartBracketHandler{bracketService: bracketService, roomService: roomService}
+}
+
+type StartBracketRequest struct {
+	MatchSeconds int `json:"match_seconds"`
+}
+
+func (h *StartBracketHandler) Handle(c *fiber.Ctx) error {
+	roomID := c.Locals("room_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	var req StartBracketRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Errorf(c.Context(), "StartBracket Handle BodyParser error: %v", err)
+
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Invalid request body"},
+		)
+	}
+
+	if req.MatchSeconds < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Match duration must be positive"},
+		)
+	}
+	if req.MatchSeconds == 0 {
+		req.MatchSeconds = defaultMatchSeconds
+	}
+
+	room, err := h.roomService.GetByID(c.Context(), roomID)
+	if err != nil {
+		logger.Errorf(c.Context(), "StartBracket Handle GetByID error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get room"},
+		)
+	}
+
+	if room.OwnerID != userID {
+		logger.Errorf(c.Context(), "StartBracket Handle unauthorized user: %v", userID)
+
+		return c.Status(fiber.StatusForbidden).JSON(
+			fiber.Map{"error": "You are not the owner of this room"},
+		)
+	}
+
+	bracket, err := h.bracketService.Start(c.Context(), roomID, userID, req.MatchSeconds)
+	if errors.Is(err, brackets.ErrBracketActive) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Bracket is already running in this room"},
+		)
+	}
+
+	if errors.Is(err, brackets.ErrNotEnoughGames) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			fiber.Map{"error": "Bracket needs at least two games"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "StartBracket Handle Start error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to start bracket"},
+		)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(bracket)
+}
@@ -0,0 +1,65 @@
+package brackets
+
+import (
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/brackets"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type VoteMatchHandler struct {
+	bracketService brackets.BracketService
+}
+
+func NewVoteMatchHandler(bracketService brackets.BracketService) *VoteMatchHandler {
+	return &VoteMatchHandler{bracketService: bracketService}
+}
+
+type VoteMatchRequest struct {
+	GameID string `json:"game_id"`
+}
+
+func (h *VoteMatchHandler) Handle(c *fiber.Ctx) error {
+	roomID := c.Locals("room_id").(string)
+	userID := c.Locals("user_id").(string)
+	matchID := c.Params("match_id")
+
+	var req VoteMatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Errorf(c.Context(), "VoteMatch Handle BodyParser error: %v", err)
+
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Invalid request body"},
+		)
+	}
+
+	match, err := h.bracketService.Vote(c.Context(), roomID, matchID, userID, req.GameID)
+	if errors.Is(err, brackets.ErrMatchNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Match not found"},
+		)
+	}
+
+	if errors.Is(err, brackets.ErrInvalidMatchVote) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Game does not play in this match"},
+		)
+	}
+
+	if errors.Is(err, brackets.ErrMatchNotOpen) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Match is not open for voting"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "VoteMatch Handle Vote error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to vote in match"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(match)
+}
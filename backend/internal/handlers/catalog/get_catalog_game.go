@@ -0,0 +1,53 @@
+package catalog
+
+import (
+	"errors"
+
+	entitiescatalog "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/catalog"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/catalog"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GetCatalogGameHandler struct {
+	catalogService catalog.CatalogService
+}
+
+func NewGetCatalogGameHandler(catalogService catalog.CatalogService) *GetCatalogGameHandler {
+	return &GetCatalogGameHandler{catalogService: catalogService}
+}
+
+type GetCatalogGameResponse struct {
+	entitiescatalog.Game
+	Stats entitiescatalog.Stats `json:"stats"`
+}
+
+func (h *GetCatalogGameHandler) Handle(c *fiber.Ctx) error {
+	catalog_id := c.Params("catalog_id")
+
+	game, err := h.catalogService.Get(c.Context(), catalog_id)
+	if errors.Is(err, catalog.ErrCatalogGameNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Catalog game not found"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "GetCatalogGame Handle Get error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get catalog game"},
+		)
+	}
+
+	stats, err := h.catalogService.Stats(c.Context(), catalog_id)
+	if err != nil {
+		logger.Errorf(c.Context(), "GetCatalogGame Handle Stats error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get catalog game stats"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(GetCatalogGameResponse{Game: game, Stats: stats})
+}
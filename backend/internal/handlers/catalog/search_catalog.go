@@ -0,0 +1,35 @@
+package catalog
+
+import (
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/catalog"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SearchCatalogHandler struct {
+	catalogService catalog.CatalogService
+}
+
+func NewSearchCatalogHandler(catalogService catalog.CatalogService) *SearchCatalogHandler {
+	return &SearchCatalogHandler{catalogService: catalogService}
+}
+
+func (h *SearchCatalogHandler) Handle(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", catalog.DefaultSearchLimit)
+	if limit <= 0 || limit > catalog.MaxSearchLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Invalid limit"},
+		)
+	}
+
+	games, err := h.catalogService.Search(c.Context(), c.Query("q"), limit)
+	if err != nil {
+		logger.Errorf(c.Context(), "SearchCatalog Handle Search error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to search catalog"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(games)
+}
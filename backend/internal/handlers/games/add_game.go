@@ -1,8 +1,14 @@
 package games
 
 import (
+	"context"
+	"errors"
+	"strings"
+
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/catalog"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/games"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/library"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/participants"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
 	"github.com/gofiber/fiber/v2"
@@ -18,8 +24,23 @@ func NewAddGameHandler(gameService games.GameService, participantService partici
 	return &AddGameHandler{gameService: gameService, participantService: participantService}
 }
 
+// AddGameRequest - если указан CatalogID, игра добавляется из каталога
+// с каталожным описанием, а если LibraryGameID - из библиотеки пользователя;
+// остальные поля в этих случаях игнорируются.
+// BroughtBy - участник, который приносит игру; игру из библиотеки приносит её владелец.
+// Force добавляет игру, даже если в комнате есть похожие.
 type AddGameRequest struct {
-	Title string `json:"title"`
+	CatalogID       string   `json:"catalog_id"`
+	LibraryGameID   string   `json:"library_game_id"`
+	BroughtBy       string   `json:"brought_by"`
+	Force           bool     `json:"force"`
+	Title           string   `json:"title"`
+	MinPlayers      int      `json:"min_players"`
+	MaxPlayers      int      `json:"max_players"`
+	DurationMinutes int      `json:"duration_minutes"`
+	Tags            []string `json:"tags"`
+	Link            string   `json:"link"`
+	Notes           string   `json:"notes"`
 }
 
 func (h *AddGameHandler) Handle(c *fiber.Ctx) error {
@@ -33,12 +54,97 @@ func (h *AddGameHandler) Handle(c *fiber.Ctx) error {
 	}
 
 	room_id := c.Locals("room_id").(string)
+	user_id := c.Locals("user_id").(string)
+
+	if req.BroughtBy != "" && req.LibraryGameID == "" {
+		ok, err := isParticipant(c.Context(), h.participantService, room_id, req.BroughtBy)
+		if err != nil {
+			logger.Errorf(c.Context(), "AddGame Handle Get participant error: %v", err)
+
+			return c.Status(fiber.StatusInternalServerError).JSON(
+				fiber.Map{"error": "Failed to add game"},
+			)
+		}
+
+		if !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(
+				fiber.Map{"error": "brought_by must be a participant of the room"},
+			)
+		}
+	}
+
+	if req.CatalogID != "" {
+		game, err := h.gameService.AddFromCatalog(c.Context(), room_id, req.CatalogID, req.BroughtBy, req.Force)
+		if errors.Is(err, catalog.ErrCatalogGameNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(
+				fiber.Map{"error": "Catalog game not found"},
+			)
+		}
+
+		var duplicateErr *games.DuplicateError
+		if errors.As(err, &duplicateErr) {
+			return duplicate(c, duplicateErr)
+		}
+
+		if err != nil {
+			logger.Errorf(c.Context(), "AddGame Handle AddFromCatalog error: %v", err)
+
+			return c.Status(fiber.StatusInternalServerError).JSON(
+				fiber.Map{"error": "Failed to add game"},
+			)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(game)
+	}
+
+	if req.LibraryGameID != "" {
+		game, err := h.gameService.AddFromLibrary(c.Context(), room_id, user_id, req.LibraryGameID, req.Force)
+		if errors.Is(err, library.ErrLibraryGameNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(
+				fiber.Map{"error": "Library game not found"},
+			)
+		}
+
+		var duplicateErr *games.DuplicateError
+		if errors.As(err, &duplicateErr) {
+			return duplicate(c, duplicateErr)
+		}
+
+		if err != nil {
+			logger.Errorf(c.Context(), "AddGame Handle AddFromLibrary error: %v", err)
+
+			return c.Status(fiber.StatusInternalServerError).JSON(
+				fiber.Map{"error": "Failed to add game"},
+			)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(game)
+	}
+
+	game := rooms.Game{
+		ID:              uuid.New().String(),
+		RoomID:          room_id,
+		Title:           strings.TrimSpace(req.Title),
+		MinPlayers:      req.MinPlayers,
+		MaxPlayers:      req.MaxPlayers,
+		DurationMinutes: req.DurationMinutes,
+		Tags:            rooms.NormalizeTags(req.Tags),
+		Link:            strings.TrimSpace(req.Link),
+		Notes:           req.Notes,
+		BroughtBy:       req.BroughtBy,
+	}
+	if msg := games.Validate(game); msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": msg},
+		)
+	}
+
+	game, err := h.gameService.Add(c.Context(), game, user_id, req.Force)
+	var duplicateErr *games.DuplicateError
+	if errors.As(err, &duplicateErr) {
+		return duplicate(c, duplicateErr)
+	}
 
-	game, err := h.gameService.Add(c.Context(), rooms.Game{
-		ID:     uuid.New().String(),
-		RoomID: room_id,
-		Title:  req.Title,
-	})
 	if err != nil {
 		logger.Errorf(c.Context(), "AddGame Handle Add error: %v", err)
 
@@ -49,3 +155,23 @@ func (h *AddGameHandler) Handle(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusCreated).JSON(game)
 }
+
+// isParticipant проверяет, что userID - участник комнаты roomID. Неверный ID участником не считается.
+func isParticipant(ctx context.Context, participantService participants.ParticipantService, roomID, userID string) (bool, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return false, nil
+	}
+
+	participant, err := participantService.Get(ctx, roomID, userID)
+	if err != nil {
+		return false, err
+	}
+	return participant.ID != "", nil
+}
+
+// duplicate отвечает 409 со списком похожих игр комнаты.
+func duplicate(c *fiber.Ctx, err *games.DuplicateError) error {
+	return c.Status(fiber.StatusConflict).JSON(
+		fiber.Map{"error": "Similar games already exist in the room", "candidates": err.Candidates},
+	)
+}
@@ -1,6 +1,8 @@
 package games
 
 import (
+	"errors"
+
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/games"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/participants"
@@ -37,7 +39,14 @@ func (h *DeleteGameHandler) Handle(c *fiber.Ctx) error {
 		)
 	}
 
-	if err := h.gameService.Delete(c.Context(), game_id, room_id); err != nil {
+	err = h.gameService.Delete(c.Context(), game_id, room_id)
+	if errors.Is(err, games.ErrGameNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Game not found"},
+		)
+	}
+
+	if err != nil {
 		logger.Errorf(c.Context(), "DeleteGame Handle Delete error: %v", err)
 
 		return c.Status(fiber.StatusInternalServerError).JSON(
@@ -0,0 +1,56 @@
+package games
+
+import (
+	"bytes"
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/games"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ExportGamesHandler struct {
+	gameService games.GameService
+}
+
+func NewExportGamesHandler(gameService games.GameService) *ExportGamesHandler {
+	return &ExportGamesHandler{gameService: gameService}
+}
+
+func (h *ExportGamesHandler) Handle(c *fiber.Ctx) error {
+	room_id := c.Locals("room_id").(string)
+	format := c.Query("format", games.ImportFormatJSON)
+
+	list, err := h.gameService.GetAllRoomGames(c.Context(), room_id)
+	if err != nil {
+		logger.Errorf(c.Context(), "ExportGames Handle GetAllRoomGames error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get games"},
+		)
+	}
+
+	var buf bytes.Buffer
+	err = games.Export(format, &buf, list)
+	if errors.Is(err, games.ErrUnknownImportFormat) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Unknown export format"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "ExportGames Handle Export error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to export games"},
+		)
+	}
+
+	if format == games.ImportFormatCSV {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	} else {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	}
+	c.Attachment("games." + format)
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
@@ -0,0 +1,29 @@
+package games
+
+import (
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/games"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GetArchivedGamesHandler struct {
+	gameService games.GameService
+}
+
+func NewGetArchivedGamesHandler(gameService games.GameService) *GetArchivedGamesHandler {
+	return &GetArchivedGamesHandler{gameService: gameService}
+}
+
+func (h *GetArchivedGamesHandler) Handle(c *fiber.Ctx) error {
+	room_id := c.Locals("room_id").(string)
+	games, err := h.gameService.GetArchived(c.Context(), room_id)
+	if err != nil {
+		logger.Errorf(c.Context(), "GetArchivedGames Handle GetArchived error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get archived games"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(games)
+}
@@ -0,0 +1,30 @@
+package games
+
+import (
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/library"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GetGameOwnersHandler struct {
+	libraryService library.LibraryService
+}
+
+func NewGetGameOwnersHandler(libraryService library.LibraryService) *GetGameOwnersHandler {
+	return &GetGameOwnersHandler{libraryService: libraryService}
+}
+
+func (h *GetGameOwnersHandler) Handle(c *fiber.Ctx) error {
+	room_id := c.Locals("room_id").(string)
+
+	owners, err := h.libraryService.GetRoomOwners(c.Context(), room_id)
+	if err != nil {
+		logger.Errorf(c.Context(), "GetGameOwners Handle GetRoomOwners error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get game owners"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(owners)
+}
@@ -0,0 +1,94 @@
+package games
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/games"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ImportGamesHandler struct {
+	gameService games.GameService
+}
+
+func NewImportGamesHandler(gameService games.GameService) *ImportGamesHandler {
+	return &ImportGamesHandler{gameService: gameService}
+}
+
+func (h *ImportGamesHandler) Handle(c *fiber.Ctx) error {
+	room_id := c.Locals("room_id").(string)
+	user_id := c.Locals("user_id").(string)
+
+	file, err := importFile(c)
+	if err != nil {
+		logger.Errorf(c.Context(), "ImportGames Handle read file error: %v", err)
+
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Invalid import file"},
+		)
+	}
+	defer file.Close()
+
+	parsed, err := games.Parse(c.Query("format"), file)
+	if errors.Is(err, games.ErrUnknownImportFormat) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Unknown import format"},
+		)
+	}
+
+	if errors.Is(err, games.ErrTooManyImportGames) {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(
+			fiber.Map{"error": "Too many games to import"},
+		)
+	}
+
+	var importErr *games.ImportError
+	if errors.As(err, &importErr) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			fiber.Map{"error": "Import file has invalid games", "rows": importErr.Rows},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "ImportGames Handle Parse error: %v", err)
+
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Invalid import file"},
+		)
+	}
+
+	res, err := h.gameService.Import(c.Context(), room_id, user_id, parsed)
+	if errors.As(err, &importErr) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			fiber.Map{"error": "Import file has invalid games", "rows": importErr.Rows},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "ImportGames Handle Import error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to import games"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(res)
+}
+
+// importFile возвращает загруженный файл: из формы, если запрос multipart/form-data, иначе тело запроса.
+func importFile(c *fiber.Ctx) (io.ReadCloser, error) {
+	if !strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		return io.NopCloser(bytes.NewReader(c.Body())), nil
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+
+	return header.Open()
+}
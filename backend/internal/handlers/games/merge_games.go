@@ -0,0 +1,57 @@
+package games
+
+import (
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/games"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type MergeGamesHandler struct {
+	gameService games.GameService
+}
+
+func NewMergeGamesHandler(gameService games.GameService) *MergeGamesHandler {
+	return &MergeGamesHandler{gameService: gameService}
+}
+
+// MergeGamesRequest - Into - игра, в которую сливается игра из пути запроса.
+type MergeGamesRequest struct {
+	Into string `json:"into"`
+}
+
+func (h *MergeGamesHandler) Handle(c *fiber.Ctx) error {
+	room_id := c.Locals("room_id").(string)
+	game_id := c.Params("game_id")
+
+	var req MergeGamesRequest
+	if err := c.BodyParser(&req); err != nil || req.Into == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Invalid request body"},
+		)
+	}
+
+	game, err := h.gameService.Merge(c.Context(), room_id, game_id, req.Into)
+	if errors.Is(err, games.ErrMergeSameGame) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Cannot merge a game into itself"},
+		)
+	}
+
+	if errors.Is(err, games.ErrGameNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Game not found"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "MergeGames Handle Merge error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to merge games"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(game)
+}
@@ -0,0 +1,39 @@
+package games
+
+import (
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/games"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type RestoreGameHandler struct {
+	gameService games.GameService
+}
+
+func NewRestoreGameHandler(gameService games.GameService) *RestoreGameHandler {
+	return &RestoreGameHandler{gameService: gameService}
+}
+
+func (h *RestoreGameHandler) Handle(c *fiber.Ctx) error {
+	room_id := c.Locals("room_id").(string)
+	game_id := c.Params("game_id")
+
+	game, err := h.gameService.Restore(c.Context(), game_id, room_id)
+	if errors.Is(err, games.ErrGameNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Archived game not found"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "RestoreGame Handle Restore error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to restore game"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(game)
+}
@@ -0,0 +1,132 @@
+package games
+
+import (
+	"strings"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/games"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/participants"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type UpdateGameHandler struct {
+	gameService        games.GameService
+	participantService participants.ParticipantService
+}
+
+func NewUpdateGameHandler(gameService games.GameService, participantService participants.ParticipantService) *UpdateGameHandler {
+	return &UpdateGameHandler{gameService: gameService, participantService: participantService}
+}
+
+// UpdateGameRequest - поля, которые не переданы, остаются без изменений.
+// Пустой BroughtBy снимает отметку о том, кто приносит игру.
+type UpdateGameRequest struct {
+	Title           *string   `json:"title,omitempty"`
+	MinPlayers      *int      `json:"min_players,omitempty"`
+	MaxPlayers      *int      `json:"max_players,omitempty"`
+	DurationMinutes *int      `json:"duration_minutes,omitempty"`
+	Tags            *[]string `json:"tags,omitempty"`
+	Link            *string   `json:"link,omitempty"`
+	Notes           *string   `json:"notes,omitempty"`
+	BroughtBy       *string   `json:"brought_by,omitempty"`
+}
+
+func (h *UpdateGameHandler) Handle(c *fiber.Ctx) error {
+	room_id := c.Locals("room_id").(string)
+	game_id := c.Params("game_id")
+
+	var req UpdateGameRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Errorf(c.Context(), "UpdateGame Handle BodyParser error: %v", err)
+
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Invalid request body"},
+		)
+	}
+
+	game, err := h.gameService.Get(c.Context(), game_id)
+	if err != nil {
+		logger.Errorf(c.Context(), "UpdateGame Handle Get game error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get game"},
+		)
+	}
+
+	if game.ID == "" || game.IsArchived() {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Game not found"},
+		)
+	}
+
+	if game.RoomID != room_id {
+		return c.Status(fiber.StatusForbidden).JSON(
+			fiber.Map{"error": "You are not allowed to edit this game from another room"},
+		)
+	}
+
+	if req.Title != nil {
+		game.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.MinPlayers != nil {
+		game.MinPlayers = *req.MinPlayers
+	}
+	if req.MaxPlayers != nil {
+		game.MaxPlayers = *req.MaxPlayers
+	}
+	if req.DurationMinutes != nil {
+		game.DurationMinutes = *req.DurationMinutes
+	}
+	if req.Tags != nil {
+		game.Tags = rooms.NormalizeTags(*req.Tags)
+	}
+	if req.Link != nil {
+		game.Link = strings.TrimSpace(*req.Link)
+	}
+	if req.Notes != nil {
+		game.Notes = *req.Notes
+	}
+	if req.BroughtBy != nil && *req.BroughtBy != game.BroughtBy {
+		if *req.BroughtBy != "" {
+			ok, err := isParticipant(c.Context(), h.participantService, room_id, *req.BroughtBy)
+			if err != nil {
+				logger.Errorf(c.Context(), "UpdateGame Handle Get participant error: %v", err)
+
+				return c.Status(fiber.StatusInternalServerError).JSON(
+					fiber.Map{"error": "Failed to update game"},
+				)
+			}
+
+			if !ok {
+				return c.Status(fiber.StatusBadRequest).JSON(
+					fiber.Map{"error": "brought_by must be a participant of the room"},
+				)
+			}
+		}
+		game.BroughtBy = *req.BroughtBy
+	}
+
+	if msg := games.Validate(game); msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": msg},
+		)
+	}
+
+	updated, err := h.gameService.Update(c.Context(), game)
+	if err != nil {
+		logger.Errorf(c.Context(), "UpdateGame Handle Update error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to update game"},
+		)
+	}
+
+	if updated.ID == "" {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Game not found"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(updated)
+}
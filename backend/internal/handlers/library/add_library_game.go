@@ -0,0 +1,79 @@
+package library
+
+import (
+	"errors"
+	"strings"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/profile"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/games"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/library"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AddLibraryGameHandler struct {
+	libraryService library.LibraryService
+}
+
+func NewAddLibraryGameHandler(libraryService library.LibraryService) *AddLibraryGameHandler {
+	return &AddLibraryGameHandler{libraryService: libraryService}
+}
+
+type AddLibraryGameRequest struct {
+	Title           string   `json:"title"`
+	MinPlayers      int      `json:"min_players"`
+	MaxPlayers      int      `json:"max_players"`
+	DurationMinutes int      `json:"duration_minutes"`
+	Tags            []string `json:"tags"`
+	Link            string   `json:"link"`
+	Notes           string   `json:"notes"`
+}
+
+func (h *AddLibraryGameHandler) Handle(c *fiber.Ctx) error {
+	var req AddLibraryGameRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Errorf(c.Context(), "AddLibraryGame Handle BodyParser error: %v", err)
+
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Invalid request body"},
+		)
+	}
+
+	user_id := c.Locals("user_id").(string)
+
+	game := profile.LibraryGame{
+		ID:              uuid.New().String(),
+		UserID:          user_id,
+		Title:           strings.TrimSpace(req.Title),
+		MinPlayers:      req.MinPlayers,
+		MaxPlayers:      req.MaxPlayers,
+		DurationMinutes: req.DurationMinutes,
+		Tags:            rooms.NormalizeTags(req.Tags),
+		Link:            strings.TrimSpace(req.Link),
+		Notes:           req.Notes,
+	}
+	if msg := games.Validate(game.Game()); msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": msg},
+		)
+	}
+
+	game, err := h.libraryService.Add(c.Context(), game)
+	if errors.Is(err, library.ErrLibraryDuplicate) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Game is already in the library"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "AddLibraryGame Handle Add error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to add game to library"},
+		)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(game)
+}
@@ -0,0 +1,39 @@
+package library
+
+import (
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/library"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type DeleteLibraryGameHandler struct {
+	libraryService library.LibraryService
+}
+
+func NewDeleteLibraryGameHandler(libraryService library.LibraryService) *DeleteLibraryGameHandler {
+	return &DeleteLibraryGameHandler{libraryService: libraryService}
+}
+
+func (h *DeleteLibraryGameHandler) Handle(c *fiber.Ctx) error {
+	user_id := c.Locals("user_id").(string)
+	library_game_id := c.Params("library_game_id")
+
+	err := h.libraryService.Delete(c.Context(), library_game_id, user_id)
+	if errors.Is(err, library.ErrLibraryGameNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Library game not found"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "DeleteLibraryGame Handle Delete error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to delete library game"},
+		)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,30 @@
+package library
+
+import (
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/library"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GetLibraryHandler struct {
+	libraryService library.LibraryService
+}
+
+func NewGetLibraryHandler(libraryService library.LibraryService) *GetLibraryHandler {
+	return &GetLibraryHandler{libraryService: libraryService}
+}
+
+func (h *GetLibraryHandler) Handle(c *fiber.Ctx) error {
+	user_id := c.Locals("user_id").(string)
+
+	games, err := h.libraryService.GetAll(c.Context(), user_id)
+	if err != nil {
+		logger.Errorf(c.Context(), "GetLibrary Handle GetAll error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get library"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(games)
+}
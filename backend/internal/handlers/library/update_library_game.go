@@ -0,0 +1,105 @@
+package library
+
+import (
+	"errors"
+	"strings"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/games"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/library"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type UpdateLibraryGameHandler struct {
+	libraryService library.LibraryService
+}
+
+func NewUpdateLibraryGameHandler(libraryService library.LibraryService) *UpdateLibraryGameHandler {
+	return &UpdateLibraryGameHandler{libraryService: libraryService}
+}
+
+// UpdateLibraryGameRequest - поля, которые не переданы, остаются без изменений.
+type UpdateLibraryGameRequest struct {
+	Title           *string   `json:"title,omitempty"`
+	MinPlayers      *int      `json:"min_players,omitempty"`
+	MaxPlayers      *int      `json:"max_players,omitempty"`
+	DurationMinutes *int      `json:"duration_minutes,omitempty"`
+	Tags            *[]string `json:"tags,omitempty"`
+	Link            *string   `json:"link,omitempty"`
+	Notes           *string   `json:"notes,omitempty"`
+}
+
+func (h *UpdateLibraryGameHandler) Handle(c *fiber.Ctx) error {
+	user_id := c.Locals("user_id").(string)
+	library_game_id := c.Params("library_game_id")
+
+	var req UpdateLibraryGameRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Errorf(c.Context(), "UpdateLibraryGame Handle BodyParser error: %v", err)
+
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Invalid request body"},
+		)
+	}
+
+	game, err := h.libraryService.Get(c.Context(), library_game_id, user_id)
+	if errors.Is(err, library.ErrLibraryGameNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Library game not found"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "UpdateLibraryGame Handle Get error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get library game"},
+		)
+	}
+
+	if req.Title != nil {
+		game.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.MinPlayers != nil {
+		game.MinPlayers = *req.MinPlayers
+	}
+	if req.MaxPlayers != nil {
+		game.MaxPlayers = *req.MaxPlayers
+	}
+	if req.DurationMinutes != nil {
+		game.DurationMinutes = *req.DurationMinutes
+	}
+	if req.Tags != nil {
+		game.Tags = rooms.NormalizeTags(*req.Tags)
+	}
+	if req.Link != nil {
+		game.Link = strings.TrimSpace(*req.Link)
+	}
+	if req.Notes != nil {
+		game.Notes = *req.Notes
+	}
+
+	if msg := games.Validate(game.Game()); msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": msg},
+		)
+	}
+
+	updated, err := h.libraryService.Update(c.Context(), game)
+	if errors.Is(err, library.ErrLibraryGameNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Library game not found"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "UpdateLibraryGame Handle Update error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to update library game"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(updated)
+}
@@ -25,8 +25,28 @@ func (h *GetParticipantsHandler) Handle(c *fiber.Ctx) error {
 		)
 	}
 
+	readiness, err := h.participantService.GetReadiness(c.Context(), roomID)
+	if err != nil {
+		logger.Errorf(c.Context(), "GetParticipants Handle GetReadiness error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get participants"},
+		)
+	}
+
+	presence, err := h.participantService.GetPresence(c.Context(), roomID)
+	if err != nil {
+		logger.Errorf(c.Context(), "GetParticipants Handle GetPresence error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get participants"},
+		)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"users": users,
-		"roles": roles,
+		"users":     users,
+		"roles":     roles,
+		"readiness": readiness,
+		"presence":  presence,
 	})
 }
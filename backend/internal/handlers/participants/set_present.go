@@ -0,0 +1,60 @@
+package participants
+
+import (
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/participants"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SetPresentHandler struct {
+	participantService participants.ParticipantService
+}
+
+func NewSetPresentHandler(participantService participants.ParticipantService) *SetPresentHandler {
+	return &SetPresentHandler{participantService: participantService}
+}
+
+// SetPresentRequest - без поля present участник отмечается присутствующим.
+type SetPresentRequest struct {
+	Present *bool `json:"present,omitempty"`
+}
+
+func (h *SetPresentHandler) Handle(c *fiber.Ctx) error {
+	roomID := c.Locals("room_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	var req SetPresentRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			logger.Errorf(c.Context(), "SetPresent Handle BodyParser error: %v", err)
+
+			return c.Status(fiber.StatusBadRequest).JSON(
+				fiber.Map{"error": "Invalid request body"},
+			)
+		}
+	}
+
+	present := true
+	if req.Present != nil {
+		present = *req.Present
+	}
+
+	res, err := h.participantService.SetPresent(c.Context(), roomID, userID, present)
+	if errors.Is(err, participants.ErrNotParticipant) {
+		return c.Status(fiber.StatusForbidden).JSON(
+			fiber.Map{"error": "You are not a participant of this room"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "SetPresent Handle SetPresent error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to set presence"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(res)
+}
@@ -0,0 +1,60 @@
+package participants
+
+import (
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/participants"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SetReadyHandler struct {
+	participantService participants.ParticipantService
+}
+
+func NewSetReadyHandler(participantService participants.ParticipantService) *SetReadyHandler {
+	return &SetReadyHandler{participantService: participantService}
+}
+
+// SetReadyRequest - без поля ready участник отмечается готовым.
+type SetReadyRequest struct {
+	Ready *bool `json:"ready,omitempty"`
+}
+
+func (h *SetReadyHandler) Handle(c *fiber.Ctx) error {
+	roomID := c.Locals("room_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	var req SetReadyRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			logger.Errorf(c.Context(), "SetReady Handle BodyParser error: %v", err)
+
+			return c.Status(fiber.StatusBadRequest).JSON(
+				fiber.Map{"error": "Invalid request body"},
+			)
+		}
+	}
+
+	ready := true
+	if req.Ready != nil {
+		ready = *req.Ready
+	}
+
+	res, err := h.participantService.SetReady(c.Context(), roomID, userID, ready)
+	if errors.Is(err, participants.ErrNotParticipant) {
+		return c.Status(fiber.StatusForbidden).JSON(
+			fiber.Map{"error": "You are not a participant of this room"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "SetReady Handle SetReady error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to set ready"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(res)
+}
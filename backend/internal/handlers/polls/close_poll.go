@@ -0,0 +1,58 @@
+package polls
+
+import (
+	"errors"
+
+	servicepolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/polls"
+	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ClosePollHandler struct {
+	pollService servicepolls.PollService
+	roomService servicerooms.RoomService
+}
+
+func NewClosePollHandler(pollService servicepolls.PollService, roomService servicerooms.RoomService) *ClosePollHandler {
+	return &ClosePollHandler{pollService: pollService, roomService: roomService}
+}
+
+func (h *ClosePollHandler) Handle(c *fiber.Ctx) error {
+	roomID := c.Locals("room_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	room, err := h.roomService.GetByID(c.Context(), roomID)
+	if err != nil {
+		logger.Errorf(c.Context(), "ClosePoll Handle GetByID error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get room"},
+		)
+	}
+
+	if room.OwnerID != userID {
+		logger.Errorf(c.Context(), "ClosePoll Handle unauthorized user: %v", userID)
+
+		return c.Status(fiber.StatusForbidden).JSON(
+			fiber.Map{"error": "You are not the owner of this room"},
+		)
+	}
+
+	poll, err := h.pollService.Close(c.Context(), roomID)
+	if errors.Is(err, servicepolls.ErrPollNotOpen) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Current poll is not open"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "ClosePoll Handle Close error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to close poll"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(poll)
+}
@@ -0,0 +1,29 @@
+package polls
+
+import (
+	servicepolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/polls"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GetCurrentPollHandler struct {
+	pollService servicepolls.PollService
+}
+
+func NewGetCurrentPollHandler(pollService servicepolls.PollService) *GetCurrentPollHandler {
+	return &GetCurrentPollHandler{pollService: pollService}
+}
+
+func (h *GetCurrentPollHandler) Handle(c *fiber.Ctx) error {
+	roomID := c.Locals("room_id").(string)
+	poll, err := h.pollService.Current(c.Context(), roomID)
+	if err != nil {
+		logger.Errorf(c.Context(), "GetCurrentPoll Handle Current error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get current poll"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(poll)
+}
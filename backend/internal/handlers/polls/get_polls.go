@@ -0,0 +1,29 @@
+package polls
+
+import (
+	servicepolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/polls"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GetPollsHandler struct {
+	pollService servicepolls.PollService
+}
+
+func NewGetPollsHandler(pollService servicepolls.PollService) *GetPollsHandler {
+	return &GetPollsHandler{pollService: pollService}
+}
+
+func (h *GetPollsHandler) Handle(c *fiber.Ctx) error {
+	roomID := c.Locals("room_id").(string)
+	polls, err := h.pollService.GetAll(c.Context(), roomID)
+	if err != nil {
+		logger.Errorf(c.Context(), "GetPolls Handle GetAll error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get polls"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(polls)
+}
@@ -0,0 +1,50 @@
+package polls
+
+import (
+	servicepolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/polls"
+	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type OpenPollHandler struct {
+	pollService servicepolls.PollService
+	roomService servicerooms.RoomService
+}
+
+func NewOpenPollHandler(pollService servicepolls.PollService, roomService servicerooms.RoomService) *OpenPollHandler {
+	return &OpenPollHandler{pollService: pollService, roomService: roomService}
+}
+
+func (h *OpenPollHandler) Handle(c *fiber.Ctx) error {
+	roomID := c.Locals("room_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	room, err := h.roomService.GetByID(c.Context(), roomID)
+	if err != nil {
+		logger.Errorf(c.Context(), "OpenPoll Handle GetByID error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get room"},
+		)
+	}
+
+	if room.OwnerID != userID {
+		logger.Errorf(c.Context(), "OpenPoll Handle unauthorized user: %v", userID)
+
+		return c.Status(fiber.StatusForbidden).JSON(
+			fiber.Map{"error": "You are not the owner of this room"},
+		)
+	}
+
+	poll, err := h.pollService.Open(c.Context(), roomID, userID)
+	if err != nil {
+		logger.Errorf(c.Context(), "OpenPoll Handle Open error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to open poll"},
+		)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(poll)
+}
@@ -0,0 +1,87 @@
+package polls
+
+import (
+	"errors"
+	"time"
+
+	servicedeadlines "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/deadlines"
+	servicepolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/polls"
+	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SetDeadlineHandler struct {
+	deadlineService servicedeadlines.DeadlineService
+	roomService     servicerooms.RoomService
+}
+
+func NewSetDeadlineHandler(deadlineService servicedeadlines.DeadlineService, roomService servicerooms.RoomService) *SetDeadlineHandler {
+	return &SetDeadlineHandler{deadlineService: deadlineService, roomService: roomService}
+}
+
+// SetDeadlineRequest - без поля deadline срок голосования снимается.
+type SetDeadlineRequest struct {
+	Deadline *time.Time `json:"deadline,omitempty"`
+}
+
+func (h *SetDeadlineHandler) Handle(c *fiber.Ctx) error {
+	roomID := c.Locals("room_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	var req SetDeadlineRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			logger.Errorf(c.Context(), "SetDeadline Handle BodyParser error: %v", err)
+
+			return c.Status(fiber.StatusBadRequest).JSON(
+				fiber.Map{"error": "Invalid request body"},
+			)
+		}
+	}
+
+	room, err := h.roomService.GetByID(c.Context(), roomID)
+	if err != nil {
+		logger.Errorf(c.Context(), "SetDeadline Handle GetByID error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get room"},
+		)
+	}
+
+	if room.OwnerID != userID {
+		logger.Errorf(c.Context(), "SetDeadline Handle unauthorized user: %v", userID)
+
+		return c.Status(fiber.StatusForbidden).JSON(
+			fiber.Map{"error": "You are not the owner of this room"},
+		)
+	}
+
+	var deadline time.Time
+	if req.Deadline != nil {
+		deadline = *req.Deadline
+	}
+
+	poll, err := h.deadlineService.Set(c.Context(), roomID, deadline)
+	if errors.Is(err, servicepolls.ErrDeadlinePast) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Deadline must be in the future"},
+		)
+	}
+
+	if errors.Is(err, servicepolls.ErrPollNotOpen) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Current poll is not open"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "SetDeadline Handle Set error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to set poll deadline"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(poll)
+}
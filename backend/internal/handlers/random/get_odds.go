@@ -0,0 +1,45 @@
+package random
+
+import (
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/results"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GetOddsHandler struct {
+	resultService results.ResultService
+}
+
+func NewGetOddsHandler(resultService results.ResultService) *GetOddsHandler {
+	return &GetOddsHandler{resultService: resultService}
+}
+
+func (h *GetOddsHandler) Handle(c *fiber.Ctx) error {
+	room_id := c.Locals("room_id").(string)
+
+	constraints, err := parseConstraints(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Invalid pick constraints"},
+		)
+	}
+
+	odds, err := h.resultService.Odds(c.Context(), room_id, c.Query("strategy"), constraints)
+	if errors.Is(err, results.ErrUnknownStrategy) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Unknown pick strategy"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "GetOdds Handle Odds error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get pick odds"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(odds)
+}
@@ -1,13 +1,20 @@
 package random
 
 import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/results"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
 	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
 )
 
+var errNotPositive = errors.New("value must be positive")
+
 type GetRandomHandler struct {
 	resultService results.ResultService
 }
@@ -16,31 +23,143 @@ func NewGetRandomHandler(resultService results.ResultService) *GetRandomHandler
 	return &GetRandomHandler{resultService: resultService}
 }
 
+// GetRandomResponse - начатая церемония. Выпавшие игры не возвращаются:
+// их объявляет событие results.updated в LandsAt.
+type GetRandomResponse struct {
+	ID         string    `json:"id"`
+	BatchID    string    `json:"batch_id"`
+	Count      int       `json:"count"`
+	Strategy   string    `json:"strategy"`
+	Commitment string    `json:"commitment"`
+	StartedAt  time.Time `json:"started_at"`
+	LandsAt    time.Time `json:"lands_at"`
+}
+
 func (h *GetRandomHandler) Handle(c *fiber.Ctx) error {
 	room_id := c.Locals("room_id").(string)
 	user_id := c.Locals("user_id").(string)
-	randomResult, err := h.resultService.PickResult(c.Context(), room_id)
+
+	constraints, err := parseConstraints(c)
 	if err != nil {
-		logger.Errorf(c.Context(), "GetRandom Handle GenerateRandomResult error: %v", err)
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Invalid pick constraints"},
+		)
+	}
+
+	spin, err := h.resultService.Spin(c.Context(), room_id, user_id, results.PickOptions{
+		Strategy:       c.Query("strategy"),
+		IgnoreCooldown: c.QueryBool("ignore_cooldown"),
+		Count:          c.QueryInt("count", 1),
+		Constraints:    constraints,
+	})
+	if errors.Is(err, results.ErrSpinInProgress) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Pick is already in progress"},
+		)
+	}
+
+	if errors.Is(err, results.ErrInvalidCount) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Invalid number of games to pick"},
+		)
+	}
+
+	if errors.Is(err, results.ErrUnknownStrategy) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Unknown pick strategy"},
+		)
+	}
+
+	var constraintErr *results.ConstraintError
+	if errors.As(err, &constraintErr) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error":    "No games match the constraints",
+			"excluded": constraintErr.Excluded,
+		})
+	}
+
+	if errors.Is(err, results.ErrNoCandidates) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			fiber.Map{"error": "No games to pick from"},
+		)
+	}
+
+	if errors.Is(err, results.ErrNotEnoughCandidates) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			fiber.Map{"error": "Not enough games to pick from"},
+		)
+	}
+
+	if errors.Is(err, results.ErrNoBallots) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			fiber.Map{"error": "No ranked ballots to resolve"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "GetRandom Handle Spin error: %v", err)
 
 		return c.Status(fiber.StatusInternalServerError).JSON(
 			fiber.Map{"error": "Failed to get random result"},
 		)
 	}
 
-	_, err = h.resultService.Add(c.Context(), rooms.Result{
-		ID:       uuid.New().String(),
-		RoomID:   room_id,
-		GameID:   randomResult,
-		ChosenBy: user_id,
+	// Ничья вынесена в дополнительный раунд: игра будет выбрана по его итогам.
+	if spin.Runoff != nil {
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+			"tied":   spin.Tied,
+			"runoff": spin.Runoff,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(GetRandomResponse{
+		ID:         spin.ID,
+		BatchID:    spin.BatchID,
+		Count:      len(spin.Lineup),
+		Strategy:   spin.Strategy,
+		Commitment: spin.Commitment,
+		StartedAt:  spin.StartedAt,
+		LandsAt:    spin.LandsAt,
 	})
+}
+
+// parseConstraints читает ограничения розыгрыша из query: players, max_minutes,
+// списки меток через запятую tags и exclude_tags и флаг available_only.
+func parseConstraints(c *fiber.Ctx) (results.Constraints, error) {
+	players, err := positiveQuery(c, "players")
 	if err != nil {
-		logger.Errorf(c.Context(), "GetRandom Handle Add Result error: %v", err)
+		return results.Constraints{}, err
+	}
 
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			fiber.Map{"error": "Failed to save random result"},
-		)
+	maxMinutes, err := positiveQuery(c, "max_minutes")
+	if err != nil {
+		return results.Constraints{}, err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(randomResult)
+	return results.Constraints{
+		Players:       players,
+		MaxMinutes:    maxMinutes,
+		Tags:          rooms.NormalizeTags(strings.Split(c.Query("tags"), ",")),
+		ExcludeTags:   rooms.NormalizeTags(strings.Split(c.Query("exclude_tags"), ",")),
+		AvailableOnly: c.QueryBool("available_only"),
+	}, nil
+}
+
+// positiveQuery читает положительное целое из query; без параметра возвращается 0.
+func positiveQuery(c *fiber.Ctx, name string) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		logger.Errorf(c.Context(), "Invalid %v query: %v", name, err)
+
+		return 0, err
+	}
+	if n < 1 {
+		return 0, errNotPositive
+	}
+	return n, nil
 }
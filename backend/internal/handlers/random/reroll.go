@@ -0,0 +1,120 @@
+package random
+
+import (
+	"errors"
+	"time"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/results"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type RerollHandler struct {
+	resultService results.ResultService
+}
+
+func NewRerollHandler(resultService results.ResultService) *RerollHandler {
+	return &RerollHandler{resultService: resultService}
+}
+
+type RerollRequest struct {
+	Reason string `json:"reason"`
+}
+
+type RerollResponse struct {
+	ID         string    `json:"id"`
+	BatchID    string    `json:"batch_id"`
+	RerollOf   string    `json:"reroll_of"`
+	Count      int       `json:"count"`
+	Strategy   string    `json:"strategy"`
+	Commitment string    `json:"commitment"`
+	StartedAt  time.Time `json:"started_at"`
+	LandsAt    time.Time `json:"lands_at"`
+}
+
+func (h *RerollHandler) Handle(c *fiber.Ctx) error {
+	room_id := c.Locals("room_id").(string)
+	user_id := c.Locals("user_id").(string)
+	result_id := c.Params("result_id")
+
+	var req RerollRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			logger.Errorf(c.Context(), "Reroll Handle BodyParser error: %v", err)
+
+			return c.Status(fiber.StatusBadRequest).JSON(
+				fiber.Map{"error": "Invalid request body"},
+			)
+		}
+	}
+
+	spin, err := h.resultService.Reroll(c.Context(), room_id, result_id, user_id, req.Reason)
+	if errors.Is(err, results.ErrResultNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Result not found"},
+		)
+	}
+
+	if errors.Is(err, results.ErrAlreadyRerolled) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Result is already rerolled"},
+		)
+	}
+
+	if errors.Is(err, results.ErrRerollLimitReached) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Reroll limit reached"},
+		)
+	}
+
+	if errors.Is(err, results.ErrSpinInProgress) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Pick is already in progress"},
+		)
+	}
+
+	var constraintErr *results.ConstraintError
+	if errors.As(err, &constraintErr) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error":    "No games match the constraints",
+			"excluded": constraintErr.Excluded,
+		})
+	}
+
+	if errors.Is(err, results.ErrNoCandidates) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			fiber.Map{"error": "No games to pick from"},
+		)
+	}
+
+	if errors.Is(err, results.ErrNotEnoughCandidates) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			fiber.Map{"error": "Not enough games to pick from"},
+		)
+	}
+
+	if errors.Is(err, results.ErrNoBallots) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			fiber.Map{"error": "No ranked ballots to resolve"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "Reroll Handle Reroll error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to reroll result"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(RerollResponse{
+		ID:         spin.ID,
+		BatchID:    spin.BatchID,
+		RerollOf:   spin.RerollOf,
+		Count:      len(spin.Lineup),
+		Strategy:   spin.Strategy,
+		Commitment: spin.Commitment,
+		StartedAt:  spin.StartedAt,
+		LandsAt:    spin.LandsAt,
+	})
+}
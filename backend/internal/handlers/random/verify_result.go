@@ -0,0 +1,44 @@
+package random
+
+import (
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/results"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type VerifyResultHandler struct {
+	resultService results.ResultService
+}
+
+func NewVerifyResultHandler(resultService results.ResultService) *VerifyResultHandler {
+	return &VerifyResultHandler{resultService: resultService}
+}
+
+func (h *VerifyResultHandler) Handle(c *fiber.Ctx) error {
+	room_id := c.Locals("room_id").(string)
+	result_id := c.Params("result_id")
+	verification, err := h.resultService.Verify(c.Context(), room_id, result_id)
+	if errors.Is(err, results.ErrResultNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Result not found"},
+		)
+	}
+
+	if errors.Is(err, results.ErrNotVerifiable) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			fiber.Map{"error": "Result was picked without a commitment"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "VerifyResult Handle Verify error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to verify result"},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(verification)
+}
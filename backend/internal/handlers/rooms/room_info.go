@@ -17,9 +17,21 @@ func NewGetRoomInfoHandler(roomService servicerooms.RoomService) *GetRoomInfoHan
 }
 
 type GetRoomInfoResponse struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	OwnerID string `json:"owner_id"`
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	OwnerID         string `json:"owner_id"`
+	PickStrategy    string `json:"pick_strategy"`
+	VetoLimit       int    `json:"veto_limit"`
+	VoteBudget      int    `json:"vote_budget"`
+	QuadraticVoting bool   `json:"quadratic_voting"`
+	CooldownResults int    `json:"cooldown_results"`
+	CooldownDays    int    `json:"cooldown_days"`
+	AutoPick        bool   `json:"auto_pick"`
+	ReadyQuorum     int    `json:"ready_quorum"`
+	RunoffSeconds   int    `json:"runoff_seconds"`
+	RerollLimit     int    `json:"reroll_limit"`
+	Fairness        bool   `json:"fairness"`
+	AvailableOnly   bool   `json:"available_only"`
 }
 
 func (h *GetRoomInfoHandler) HandleGetRoomInfo(c *fiber.Ctx) error {
@@ -35,8 +47,20 @@ func (h *GetRoomInfoHandler) HandleGetRoomInfo(c *fiber.Ctx) error {
 	}
 
 	return c.JSON(GetRoomInfoResponse{
-		ID:      room.ID,
-		Name:    room.Name,
-		OwnerID: room.OwnerID,
+		ID:              room.ID,
+		Name:            room.Name,
+		OwnerID:         room.OwnerID,
+		PickStrategy:    room.PickStrategy,
+		VetoLimit:       room.VetoLimit,
+		VoteBudget:      room.VoteBudget,
+		QuadraticVoting: room.QuadraticVoting,
+		CooldownResults: room.CooldownResults,
+		CooldownDays:    room.CooldownDays,
+		AutoPick:        room.AutoPick,
+		ReadyQuorum:     room.ReadyQuorum,
+		RunoffSeconds:   room.RunoffSeconds,
+		RerollLimit:     room.RerollLimit,
+		Fairness:        room.Fairness,
+		AvailableOnly:   room.AvailableOnly,
 	})
 }
@@ -3,6 +3,7 @@ package rooms
 import (
 	"context"
 
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
 	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
 	"github.com/gofiber/fiber/v2"
@@ -17,13 +18,37 @@ func NewUpdateRoomHandler(roomService servicerooms.RoomService) *UpdateRoomHandl
 }
 
 type UpdateRoomRequest struct {
-	Name string `json:"name"`
+	Name            string  `json:"name"`
+	PickStrategy    *string `json:"pick_strategy,omitempty"`
+	VetoLimit       *int    `json:"veto_limit,omitempty"`
+	VoteBudget      *int    `json:"vote_budget,omitempty"`
+	QuadraticVoting *bool   `json:"quadratic_voting,omitempty"`
+	CooldownResults *int    `json:"cooldown_results,omitempty"`
+	CooldownDays    *int    `json:"cooldown_days,omitempty"`
+	AutoPick        *bool   `json:"auto_pick,omitempty"`
+	ReadyQuorum     *int    `json:"ready_quorum,omitempty"`
+	RunoffSeconds   *int    `json:"runoff_seconds,omitempty"`
+	RerollLimit     *int    `json:"reroll_limit,omitempty"`
+	Fairness        *bool   `json:"fairness,omitempty"`
+	AvailableOnly   *bool   `json:"available_only,omitempty"`
 }
 
 type UpdateRoomResponse struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	OwnerID string `json:"owner_id"`
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	OwnerID         string `json:"owner_id"`
+	PickStrategy    string `json:"pick_strategy"`
+	VetoLimit       int    `json:"veto_limit"`
+	VoteBudget      int    `json:"vote_budget"`
+	QuadraticVoting bool   `json:"quadratic_voting"`
+	CooldownResults int    `json:"cooldown_results"`
+	CooldownDays    int    `json:"cooldown_days"`
+	AutoPick        bool   `json:"auto_pick"`
+	ReadyQuorum     int    `json:"ready_quorum"`
+	RunoffSeconds   int    `json:"runoff_seconds"`
+	RerollLimit     int    `json:"reroll_limit"`
+	Fairness        bool   `json:"fairness"`
+	AvailableOnly   bool   `json:"available_only"`
 }
 
 func (h *UpdateRoomHandler) HandleUpdateRoom(c *fiber.Ctx) error {
@@ -39,6 +64,49 @@ func (h *UpdateRoomHandler) HandleUpdateRoom(c *fiber.Ctx) error {
 		)
 	}
 
+	if req.PickStrategy != nil && !rooms.IsValidPickStrategy(*req.PickStrategy) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Unknown pick strategy"},
+		)
+	}
+
+	if req.VetoLimit != nil && *req.VetoLimit < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Veto limit must not be negative"},
+		)
+	}
+
+	if req.VoteBudget != nil && *req.VoteBudget < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Vote budget must not be negative"},
+		)
+	}
+
+	if (req.CooldownResults != nil && *req.CooldownResults < 0) ||
+		(req.CooldownDays != nil && *req.CooldownDays < 0) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Cooldown must not be negative"},
+		)
+	}
+
+	if req.ReadyQuorum != nil && *req.ReadyQuorum < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Ready quorum must not be negative"},
+		)
+	}
+
+	if req.RunoffSeconds != nil && *req.RunoffSeconds < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Runoff duration must not be negative"},
+		)
+	}
+
+	if req.RerollLimit != nil && *req.RerollLimit < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Reroll limit must not be negative"},
+		)
+	}
+
 	room, err := h.roomService.GetByID(context.Background(), roomID)
 	if err != nil {
 		logger.Errorf(c.Context(), "UpdateRoom Handle GetByID error: %v", err)
@@ -57,6 +125,42 @@ func (h *UpdateRoomHandler) HandleUpdateRoom(c *fiber.Ctx) error {
 	}
 
 	room.Name = req.Name
+	if req.PickStrategy != nil {
+		room.PickStrategy = *req.PickStrategy
+	}
+	if req.VetoLimit != nil {
+		room.VetoLimit = *req.VetoLimit
+	}
+	if req.VoteBudget != nil {
+		room.VoteBudget = *req.VoteBudget
+	}
+	if req.QuadraticVoting != nil {
+		room.QuadraticVoting = *req.QuadraticVoting
+	}
+	if req.CooldownResults != nil {
+		room.CooldownResults = *req.CooldownResults
+	}
+	if req.CooldownDays != nil {
+		room.CooldownDays = *req.CooldownDays
+	}
+	if req.AutoPick != nil {
+		room.AutoPick = *req.AutoPick
+	}
+	if req.ReadyQuorum != nil {
+		room.ReadyQuorum = *req.ReadyQuorum
+	}
+	if req.RunoffSeconds != nil {
+		room.RunoffSeconds = *req.RunoffSeconds
+	}
+	if req.RerollLimit != nil {
+		room.RerollLimit = *req.RerollLimit
+	}
+	if req.Fairness != nil {
+		room.Fairness = *req.Fairness
+	}
+	if req.AvailableOnly != nil {
+		room.AvailableOnly = *req.AvailableOnly
+	}
 
 	updatedRoom, err := h.roomService.Update(c.Context(), room)
 	if err != nil {
@@ -68,9 +172,21 @@ func (h *UpdateRoomHandler) HandleUpdateRoom(c *fiber.Ctx) error {
 	}
 
 	response := UpdateRoomResponse{
-		ID:      updatedRoom.ID,
-		Name:    updatedRoom.Name,
-		OwnerID: updatedRoom.OwnerID,
+		ID:              updatedRoom.ID,
+		Name:            updatedRoom.Name,
+		OwnerID:         updatedRoom.OwnerID,
+		PickStrategy:    updatedRoom.PickStrategy,
+		VetoLimit:       updatedRoom.VetoLimit,
+		VoteBudget:      updatedRoom.VoteBudget,
+		QuadraticVoting: updatedRoom.QuadraticVoting,
+		CooldownResults: updatedRoom.CooldownResults,
+		CooldownDays:    updatedRoom.CooldownDays,
+		AutoPick:        updatedRoom.AutoPick,
+		ReadyQuorum:     updatedRoom.ReadyQuorum,
+		RunoffSeconds:   updatedRoom.RunoffSeconds,
+		RerollLimit:     updatedRoom.RerollLimit,
+		Fairness:        updatedRoom.Fairness,
+		AvailableOnly:   updatedRoom.AvailableOnly,
 	}
 
 	return c.JSON(response)
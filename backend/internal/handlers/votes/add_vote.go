@@ -1,6 +1,8 @@
 package votes
 
 import (
+	"errors"
+
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/votes"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
@@ -18,6 +20,8 @@ func NewAddVoteHandler(voteService votes.VoteService) *AddVoteHandler {
 
 type AddVoteRequest struct {
 	GameID string `json:"game_id"`
+	Kind   string `json:"kind"`
+	Points int    `json:"points"`
 }
 
 func (h *AddVoteHandler) Handle(c *fiber.Ctx) error {
@@ -28,12 +32,60 @@ func (h *AddVoteHandler) Handle(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
 	}
 
+	if req.Points < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Points must be positive"})
+	}
+
 	vote, err := h.voteService.Add(c.Context(), rooms.Vote{
 		ID:     uuid.New().String(),
 		RoomID: room_id,
 		GameID: req.GameID,
 		UserID: c.Locals("user_id").(string),
+		Kind:   req.Kind,
+		Points: req.Points,
 	})
+	if errors.Is(err, votes.ErrInvalidKind) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Unknown vote kind"},
+		)
+	}
+
+	if errors.Is(err, votes.ErrInvalidPoints) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			fiber.Map{"error": "Points are not allowed for this vote"},
+		)
+	}
+
+	if errors.Is(err, votes.ErrGameNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Game not found"},
+		)
+	}
+
+	if errors.Is(err, votes.ErrNotInRunoff) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			fiber.Map{"error": "Game is not in the runoff"},
+		)
+	}
+
+	if errors.Is(err, votes.ErrVotingClosed) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Voting in the current poll is closed"},
+		)
+	}
+
+	if errors.Is(err, votes.ErrBudgetExceeded) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Vote budget exceeded"},
+		)
+	}
+
+	if errors.Is(err, votes.ErrVetoLimitReached) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Veto limit reached"},
+		)
+	}
+
 	if err != nil {
 		logger.Errorf(c.Context(), "AddVote Handle Add error: %v", err)
 
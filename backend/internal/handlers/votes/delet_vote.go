@@ -1,6 +1,8 @@
 package votes
 
 import (
+	"errors"
+
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/votes"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
 	"github.com/gofiber/fiber/v2"
@@ -32,7 +34,14 @@ func (h *DeleteVoteHandler) Handle(c *fiber.Ctx) error {
 	}
 
 	roomID := c.Locals("room_id").(string)
-	if err := h.voteService.Delete(c.Context(), voteID, roomID); err != nil {
+	err = h.voteService.Delete(c.Context(), voteID, roomID)
+	if errors.Is(err, votes.ErrVotingClosed) {
+		return c.Status(fiber.StatusConflict).JSON(
+			fiber.Map{"error": "Voting in this poll is closed"},
+		)
+	}
+
+	if err != nil {
 		logger.Errorf(c.Context(), "DeleteVote Handle Delete error: %v", err)
 
 		return c.Status(fiber.StatusInternalServerError).JSON(
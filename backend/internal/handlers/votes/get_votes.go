@@ -1,6 +1,11 @@
 package votes
 
 import (
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	servicepolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/polls"
+	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/votes"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
 	"github.com/gofiber/fiber/v2"
@@ -8,22 +13,90 @@ import (
 
 type GetVotesHandler struct {
 	voteService votes.VoteService
+	roomService servicerooms.RoomService
+	pollService servicepolls.PollService
+}
+
+func NewGetVotesHandler(voteService votes.VoteService, roomService servicerooms.RoomService, pollService servicepolls.PollService) *GetVotesHandler {
+	return &GetVotesHandler{voteService: voteService, roomService: roomService, pollService: pollService}
+}
+
+type VoteBudget struct {
+	Total     int  `json:"total"`
+	Spent     int  `json:"spent"`
+	Quadratic bool `json:"quadratic"`
 }
 
-func NewGetVotesHandler(voteService votes.VoteService) *GetVotesHandler {
-	return &GetVotesHandler{voteService: voteService}
+type GetVotesResponse struct {
+	Poll    rooms.Poll        `json:"poll"`
+	Votes   []rooms.Vote      `json:"votes"`
+	Tallies []rooms.VoteTally `json:"tallies"`
+	Budget  VoteBudget        `json:"budget"`
 }
 
 func (h *GetVotesHandler) Handle(c *fiber.Ctx) error {
 	room_id := c.Locals("room_id").(string)
-	votesList, err := h.voteService.GetForRoom(c.Context(), room_id)
+	user_id := c.Locals("user_id").(string)
+
+	// По умолчанию возвращаются голоса текущего раунда, poll_id выбирает прошлый раунд.
+	var (
+		poll rooms.Poll
+		err  error
+	)
+	if poll_id := c.Query("poll_id"); poll_id != "" {
+		poll, err = h.pollService.Get(c.Context(), room_id, poll_id)
+	} else {
+		poll, err = h.pollService.Current(c.Context(), room_id)
+	}
+	if errors.Is(err, servicepolls.ErrPollNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			fiber.Map{"error": "Poll not found"},
+		)
+	}
+
+	if err != nil {
+		logger.Errorf(c.Context(), "GetVotes Handle GetPoll error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get poll"},
+		)
+	}
+
+	votesList, err := h.voteService.GetForPoll(c.Context(), poll.ID)
+	if err != nil {
+		logger.Errorf(c.Context(), "GetVotes Handle GetForPoll error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get votes"},
+		)
+	}
+
+	room, err := h.roomService.GetByID(c.Context(), room_id)
+	if err != nil {
+		logger.Errorf(c.Context(), "GetVotes Handle GetByID error: %v", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			fiber.Map{"error": "Failed to get room"},
+		)
+	}
+
+	spent, err := h.voteService.GetSpent(c.Context(), room_id, user_id)
 	if err != nil {
-		logger.Errorf(c.Context(), "GetVotes Handle GetForRoom error: %v", err)
+		logger.Errorf(c.Context(), "GetVotes Handle GetSpent error: %v", err)
 
 		return c.Status(fiber.StatusInternalServerError).JSON(
 			fiber.Map{"error": "Failed to get votes"},
 		)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(votesList)
+	return c.Status(fiber.StatusOK).JSON(GetVotesResponse{
+		Poll:    poll,
+		Votes:   votesList,
+		Tallies: votes.Tally(votesList),
+		Budget: VoteBudget{
+			Total:     room.VoteBudget,
+			Spent:     spent,
+			Quadratic: room.QuadraticVoting,
+		},
+	})
 }
@@ -12,14 +12,41 @@ import (
 type RoomEventType string
 
 const (
-	EventRoomUpdated      RoomEventType = "room.updated"
-	EventParticipantAdded RoomEventType = "participant.added"
-	EventParticipantLeft  RoomEventType = "participant.left"
-	EventGameAdded        RoomEventType = "game.added"
-	EventGameDeleted      RoomEventType = "game.deleted"
-	EventVoteAdded        RoomEventType = "vote.added"
-	EventVoteDeleted      RoomEventType = "vote.deleted"
-	EventResultsUpdated   RoomEventType = "results.updated"
+	EventRoomUpdated        RoomEventType = "room.updated"
+	EventParticipantAdded   RoomEventType = "participant.added"
+	EventParticipantLeft    RoomEventType = "participant.left"
+	EventParticipantReady   RoomEventType = "participant.ready"
+	EventParticipantPresent RoomEventType = "participant.present"
+	EventGameAdded          RoomEventType = "game.added"
+	EventGameDeleted        RoomEventType = "game.deleted"
+	EventGameUpdated        RoomEventType = "game.updated"
+	EventGameRestored       RoomEventType = "game.restored"
+	EventGamesBulkAdded     RoomEventType = "games.bulk_added"
+	EventGamesMerged        RoomEventType = "games.merged"
+	EventVoteAdded          RoomEventType = "vote.added"
+	EventVoteDeleted        RoomEventType = "vote.deleted"
+	EventResultsUpdated     RoomEventType = "results.updated"
+	EventBallotSubmitted    RoomEventType = "ballot.submitted"
+	EventBallotDeleted      RoomEventType = "ballot.deleted"
+	EventPickCommitted      RoomEventType = "pick.committed"
+	EventPickRevealed       RoomEventType = "pick.revealed"
+	EventPickStarted        RoomEventType = "pick.started"
+	EventPickRerolled       RoomEventType = "pick.rerolled"
+	EventPickFailed         RoomEventType = "pick.failed"
+	EventOddsUpdated        RoomEventType = "odds.updated"
+
+	EventBracketStarted     RoomEventType = "bracket.started"
+	EventBracketMatchOpened RoomEventType = "bracket.match_opened"
+	EventBracketMatchClosed RoomEventType = "bracket.match_closed"
+	EventBracketFinished    RoomEventType = "bracket.finished"
+
+	EventPollOpened     RoomEventType = "poll.opened"
+	EventPollClosed     RoomEventType = "poll.closed"
+	EventPollScheduled  RoomEventType = "poll.scheduled"
+	EventPollPickFailed RoomEventType = "poll.pick_failed"
+
+	EventRunoffStarted  RoomEventType = "runoff.started"
+	EventRunoffFinished RoomEventType = "runoff.finished"
 )
 
 // RoomEvent is a generic broadcast payload.
@@ -39,6 +66,7 @@ type Hub interface {
 	Subscribe(roomID string, cl *Client) *Client
 	Unsubscribe(roomID string, cl *Client)
 	Broadcast(roomID string, evt RoomEvent)
+	Connected(roomID string) []string
 }
 
 // Hub manages per-room websocket clients and broadcasts events.
@@ -78,6 +106,23 @@ func (h *HubWS) Unsubscribe(roomID string, cl *Client) {
 	h.mu.Unlock()
 }
 
+// Connected returns IDs of users with at least one open connection to a room.
+func (h *HubWS) Connected(roomID string) []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	seen := make(map[string]struct{}, len(h.rooms[roomID]))
+	res := make([]string, 0, len(h.rooms[roomID]))
+	for cl := range h.rooms[roomID] {
+		if _, ok := seen[cl.UserID]; ok {
+			continue
+		}
+		seen[cl.UserID] = struct{}{}
+		res = append(res, cl.UserID)
+	}
+	return res
+}
+
 // Broadcast sends an event to all clients in a room.
 func (h *HubWS) Broadcast(roomID string, evt RoomEvent) {
 	h.mu.RLock()
@@ -0,0 +1,3 @@
+-- name: Delete :exec
+DELETE FROM ballots
+WHERE poll_id = $1 AND user_id = $2;
\ No newline at end of file
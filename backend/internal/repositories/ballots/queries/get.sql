@@ -0,0 +1,4 @@
+-- name: Get :one
+SELECT *
+FROM ballots
+WHERE poll_id = $1 AND user_id = $2;
\ No newline at end of file
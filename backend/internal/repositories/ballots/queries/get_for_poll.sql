@@ -0,0 +1,5 @@
+-- name: GetForPoll :many
+SELECT *
+FROM ballots
+WHERE poll_id = $1
+ORDER BY created_at;
\ No newline at end of file
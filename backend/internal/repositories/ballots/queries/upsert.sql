@@ -0,0 +1,10 @@
+-- name: Upsert :one
+INSERT INTO ballots (
+    id, room_id, user_id, rankings, poll_id
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (poll_id, user_id) DO UPDATE
+SET rankings = EXCLUDED.rankings,
+    created_at = CURRENT_TIMESTAMP
+RETURNING *;
\ No newline at end of file
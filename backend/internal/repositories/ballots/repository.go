@@ -0,0 +1,116 @@
+package ballots
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/ballots/gen"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/google/uuid"
+)
+
+type BallotRepository interface {
+	Upsert(context.Context, UpsertParams) (entitiesrooms.Ballot, error)
+	Get(context.Context, uuid.UUID, uuid.UUID) (entitiesrooms.Ballot, error)
+	GetForPoll(context.Context, uuid.UUID) ([]entitiesrooms.Ballot, error)
+	Delete(context.Context, uuid.UUID, uuid.UUID) error
+}
+
+type Repository struct {
+	db *gen.Queries
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: gen.New(db)}
+}
+
+type UpsertParams struct {
+	ID       uuid.UUID
+	RoomID   uuid.UUID
+	UserID   uuid.UUID
+	Rankings []uuid.UUID
+	PollID   uuid.UUID
+}
+
+func (r *Repository) Upsert(ctx context.Context, params UpsertParams) (entitiesrooms.Ballot, error) {
+	ballot, err := r.db.Upsert(ctx, gen.UpsertParams{
+		ID:       params.ID,
+		RoomID:   params.RoomID,
+		UserID:   params.UserID,
+		Rankings: params.Rankings,
+		PollID:   params.PollID,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "UpsertBallot error: %v; data: %v", err, params)
+
+		return entitiesrooms.Ballot{}, err
+	}
+
+	return toEntity(ballot), nil
+}
+
+func (r *Repository) Get(ctx context.Context, pollID, userID uuid.UUID) (entitiesrooms.Ballot, error) {
+	ballot, err := r.db.Get(ctx, gen.GetParams{
+		PollID: pollID,
+		UserID: userID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.Ballot{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "GetBallot error: %v; pollID: %v, userID: %v", err, pollID, userID)
+
+		return entitiesrooms.Ballot{}, err
+	}
+
+	return toEntity(ballot), nil
+}
+
+func (r *Repository) GetForPoll(ctx context.Context, pollID uuid.UUID) ([]entitiesrooms.Ballot, error) {
+	items, err := r.db.GetForPoll(ctx, pollID)
+	if err != nil {
+		logger.Errorf(ctx, "GetBallotsForPoll error: %v; pollID: %v", err, pollID)
+
+		return nil, err
+	}
+
+	res := make([]entitiesrooms.Ballot, 0, len(items))
+	for _, it := range items {
+		res = append(res, toEntity(it))
+	}
+
+	return res, nil
+}
+
+func (r *Repository) Delete(ctx context.Context, pollID, userID uuid.UUID) error {
+	err := r.db.Delete(ctx, gen.DeleteParams{
+		PollID: pollID,
+		UserID: userID,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "DeleteBallot error: %v; pollID: %v, userID: %v", err, pollID, userID)
+
+		return err
+	}
+
+	return nil
+}
+
+func toEntity(ballot gen.Ballot) entitiesrooms.Ballot {
+	rankings := make([]string, 0, len(ballot.Rankings))
+	for _, gameID := range ballot.Rankings {
+		rankings = append(rankings, gameID.String())
+	}
+
+	return entitiesrooms.Ballot{
+		ID:        ballot.ID.String(),
+		RoomID:    ballot.RoomID.String(),
+		UserID:    ballot.UserID.String(),
+		Rankings:  rankings,
+		PollID:    ballot.PollID.String(),
+		CreatedAt: ballot.CreatedAt.Time,
+	}
+}
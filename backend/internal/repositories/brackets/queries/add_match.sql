@@ -0,0 +1,7 @@
+-- name: AddMatch :one
+INSERT INTO bracket_matches (
+    id, bracket_id, round, position, game_a, game_b, status, winner_game_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
\ No newline at end of file
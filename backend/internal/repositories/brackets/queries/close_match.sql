@@ -0,0 +1,6 @@
+-- name: CloseMatch :one
+UPDATE bracket_matches
+SET status = 'closed',
+    winner_game_id = $2
+WHERE id = $1 AND status = 'open'
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- name: Create :one
+INSERT INTO brackets (
+    id, room_id, match_seconds, created_by
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
\ No newline at end of file
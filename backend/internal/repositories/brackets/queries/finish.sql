@@ -0,0 +1,6 @@
+-- name: Finish :one
+UPDATE brackets
+SET status = $2,
+    winner_game_id = $3
+WHERE id = $1 AND status = 'active'
+RETURNING *;
\ No newline at end of file
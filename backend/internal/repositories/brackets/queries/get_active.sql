@@ -0,0 +1,4 @@
+-- name: GetActive :one
+SELECT *
+FROM brackets
+WHERE room_id = $1 AND status = 'active';
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- name: GetLatest :one
+SELECT *
+FROM brackets
+WHERE room_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- name: GetMatch :one
+SELECT m.*
+FROM bracket_matches m
+JOIN brackets b ON b.id = m.bracket_id
+WHERE m.id = $1 AND b.room_id = $2;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- name: GetMatches :many
+SELECT *
+FROM bracket_matches
+WHERE bracket_id = $1
+ORDER BY round, position;
\ No newline at end of file
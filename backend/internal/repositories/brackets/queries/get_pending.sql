@@ -0,0 +1,6 @@
+-- name: GetPending :many
+SELECT sqlc.embed(b), sqlc.embed(m)
+FROM bracket_matches m
+JOIN brackets b ON b.id = m.bracket_id
+WHERE b.status = 'active' AND m.status = 'open'
+ORDER BY m.closes_at;
\ No newline at end of file
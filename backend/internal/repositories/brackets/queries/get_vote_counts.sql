@@ -0,0 +1,6 @@
+-- name: GetVoteCounts :many
+SELECT v.match_id, v.game_id, COUNT(*) AS votes
+FROM bracket_votes v
+JOIN bracket_matches m ON m.id = v.match_id
+WHERE m.bracket_id = $1
+GROUP BY v.match_id, v.game_id;
\ No newline at end of file
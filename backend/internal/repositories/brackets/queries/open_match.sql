@@ -0,0 +1,7 @@
+-- name: OpenMatch :one
+UPDATE bracket_matches
+SET status = 'open',
+    opened_at = $2,
+    closes_at = $3
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
\ No newline at end of file
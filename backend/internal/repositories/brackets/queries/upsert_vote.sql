@@ -0,0 +1,9 @@
+-- name: UpsertVote :exec
+INSERT INTO bracket_votes (
+    match_id, user_id, game_id
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (match_id, user_id) DO UPDATE
+SET game_id = EXCLUDED.game_id,
+    created_at = CURRENT_TIMESTAMP;
\ No newline at end of file
@@ -0,0 +1,314 @@
+package brackets
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/brackets/gen"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/google/uuid"
+)
+
+type BracketRepository interface {
+	Create(context.Context, CreateParams) (entitiesrooms.Bracket, error)
+	GetActive(context.Context, uuid.UUID) (entitiesrooms.Bracket, error)
+	GetLatest(context.Context, uuid.UUID) (entitiesrooms.Bracket, error)
+	Finish(context.Context, FinishParams) (entitiesrooms.Bracket, error)
+	AddMatch(context.Context, AddMatchParams) (entitiesrooms.BracketMatch, error)
+	GetMatches(context.Context, uuid.UUID) ([]entitiesrooms.BracketMatch, error)
+	GetMatch(context.Context, uuid.UUID, uuid.UUID) (entitiesrooms.BracketMatch, error)
+	OpenMatch(context.Context, uuid.UUID, time.Time, time.Time) (entitiesrooms.BracketMatch, error)
+	CloseMatch(context.Context, uuid.UUID, uuid.UUID) (entitiesrooms.BracketMatch, error)
+	UpsertVote(context.Context, UpsertVoteParams) error
+	GetVoteCounts(context.Context, uuid.UUID) (map[string]map[string]int, error)
+	GetPending(context.Context) ([]entitiesrooms.Bracket, error)
+}
+
+type Repository struct {
+	db *gen.Queries
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: gen.New(db)}
+}
+
+type CreateParams struct {
+	ID           uuid.UUID
+	RoomID       uuid.UUID
+	MatchSeconds int32
+	CreatedBy    uuid.UUID
+}
+
+func (r *Repository) Create(ctx context.Context, params CreateParams) (entitiesrooms.Bracket, error) {
+	bracket, err := r.db.Create(ctx, gen.CreateParams{
+		ID:           params.ID,
+		RoomID:       params.RoomID,
+		MatchSeconds: params.MatchSeconds,
+		CreatedBy:    params.CreatedBy,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "CreateBracket error: %v; data: %v", err, params)
+
+		return entitiesrooms.Bracket{}, err
+	}
+
+	return toEntity(bracket), nil
+}
+
+func (r *Repository) GetActive(ctx context.Context, roomID uuid.UUID) (entitiesrooms.Bracket, error) {
+	bracket, err := r.db.GetActive(ctx, roomID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.Bracket{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "GetActiveBracket error: %v; roomID: %v", err, roomID)
+
+		return entitiesrooms.Bracket{}, err
+	}
+
+	return toEntity(bracket), nil
+}
+
+func (r *Repository) GetLatest(ctx context.Context, roomID uuid.UUID) (entitiesrooms.Bracket, error) {
+	bracket, err := r.db.GetLatest(ctx, roomID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.Bracket{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "GetLatestBracket error: %v; roomID: %v", err, roomID)
+
+		return entitiesrooms.Bracket{}, err
+	}
+
+	return toEntity(bracket), nil
+}
+
+type FinishParams struct {
+	ID           uuid.UUID
+	Status       string
+	WinnerGameID uuid.NullUUID
+}
+
+// Finish завершает активный турнир. Если турнир уже завершён, возвращает пустую сетку.
+func (r *Repository) Finish(ctx context.Context, params FinishParams) (entitiesrooms.Bracket, error) {
+	bracket, err := r.db.Finish(ctx, gen.FinishParams{
+		ID:           params.ID,
+		Status:       params.Status,
+		WinnerGameID: params.WinnerGameID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.Bracket{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "FinishBracket error: %v; data: %v", err, params)
+
+		return entitiesrooms.Bracket{}, err
+	}
+
+	return toEntity(bracket), nil
+}
+
+type AddMatchParams struct {
+	ID           uuid.UUID
+	BracketID    uuid.UUID
+	Round        int32
+	Position     int32
+	GameA        uuid.UUID
+	GameB        uuid.NullUUID
+	Status       string
+	WinnerGameID uuid.NullUUID
+}
+
+func (r *Repository) AddMatch(ctx context.Context, params AddMatchParams) (entitiesrooms.BracketMatch, error) {
+	match, err := r.db.AddMatch(ctx, gen.AddMatchParams{
+		ID:           params.ID,
+		BracketID:    params.BracketID,
+		Round:        params.Round,
+		Position:     params.Position,
+		GameA:        params.GameA,
+		GameB:        params.GameB,
+		Status:       params.Status,
+		WinnerGameID: params.WinnerGameID,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "AddBracketMatch error: %v; data: %v", err, params)
+
+		return entitiesrooms.BracketMatch{}, err
+	}
+
+	return toMatchEntity(match), nil
+}
+
+func (r *Repository) GetMatches(ctx context.Context, bracketID uuid.UUID) ([]entitiesrooms.BracketMatch, error) {
+	items, err := r.db.GetMatches(ctx, bracketID)
+	if err != nil {
+		logger.Errorf(ctx, "GetBracketMatches error: %v; bracketID: %v", err, bracketID)
+
+		return nil, err
+	}
+
+	res := make([]entitiesrooms.BracketMatch, 0, len(items))
+	for _, it := range items {
+		res = append(res, toMatchEntity(it))
+	}
+
+	return res, nil
+}
+
+func (r *Repository) GetMatch(ctx context.Context, id, roomID uuid.UUID) (entitiesrooms.BracketMatch, error) {
+	match, err := r.db.GetMatch(ctx, gen.GetMatchParams{
+		ID:     id,
+		RoomID: roomID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.BracketMatch{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "GetBracketMatch error: %v; id: %v", err, id)
+
+		return entitiesrooms.BracketMatch{}, err
+	}
+
+	return toMatchEntity(match), nil
+}
+
+// OpenMatch открывает ожидающий матч. Если матч уже открыт или завершён, возвращает пустой матч.
+func (r *Repository) OpenMatch(ctx context.Context, id uuid.UUID, openedAt, closesAt time.Time) (entitiesrooms.BracketMatch, error) {
+	match, err := r.db.OpenMatch(ctx, gen.OpenMatchParams{
+		ID:       id,
+		OpenedAt: sql.NullTime{Time: openedAt, Valid: true},
+		ClosesAt: sql.NullTime{Time: closesAt, Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.BracketMatch{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "OpenBracketMatch error: %v; id: %v", err, id)
+
+		return entitiesrooms.BracketMatch{}, err
+	}
+
+	return toMatchEntity(match), nil
+}
+
+// CloseMatch завершает открытый матч. Если матч уже завершён, возвращает пустой матч.
+func (r *Repository) CloseMatch(ctx context.Context, id, winnerGameID uuid.UUID) (entitiesrooms.BracketMatch, error) {
+	match, err := r.db.CloseMatch(ctx, gen.CloseMatchParams{
+		ID:           id,
+		WinnerGameID: uuid.NullUUID{UUID: winnerGameID, Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.BracketMatch{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "CloseBracketMatch error: %v; id: %v", err, id)
+
+		return entitiesrooms.BracketMatch{}, err
+	}
+
+	return toMatchEntity(match), nil
+}
+
+type UpsertVoteParams struct {
+	MatchID uuid.UUID
+	UserID  uuid.UUID
+	GameID  uuid.UUID
+}
+
+func (r *Repository) UpsertVote(ctx context.Context, params UpsertVoteParams) error {
+	err := r.db.UpsertVote(ctx, gen.UpsertVoteParams{
+		MatchID: params.MatchID,
+		UserID:  params.UserID,
+		GameID:  params.GameID,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "UpsertBracketVote error: %v; data: %v", err, params)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetVoteCounts возвращает число голосов по матчам турнира: ID матча -> ID игры -> голоса.
+func (r *Repository) GetVoteCounts(ctx context.Context, bracketID uuid.UUID) (map[string]map[string]int, error) {
+	items, err := r.db.GetVoteCounts(ctx, bracketID)
+	if err != nil {
+		logger.Errorf(ctx, "GetBracketVoteCounts error: %v; bracketID: %v", err, bracketID)
+
+		return nil, err
+	}
+
+	res := make(map[string]map[string]int)
+	for _, it := range items {
+		matchID := it.MatchID.String()
+		if res[matchID] == nil {
+			res[matchID] = make(map[string]int)
+		}
+		res[matchID][it.GameID.String()] = int(it.Votes)
+	}
+
+	return res, nil
+}
+
+// GetPending возвращает активные турниры всех комнат, в каждом - его открытый матч.
+func (r *Repository) GetPending(ctx context.Context) ([]entitiesrooms.Bracket, error) {
+	items, err := r.db.GetPending(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "GetPendingBrackets error: %v", err)
+
+		return nil, err
+	}
+
+	res := make([]entitiesrooms.Bracket, 0, len(items))
+	for _, it := range items {
+		bracket := toEntity(it.Bracket)
+		bracket.Matches = []entitiesrooms.BracketMatch{toMatchEntity(it.BracketMatch)}
+		res = append(res, bracket)
+	}
+
+	return res, nil
+}
+
+func toEntity(bracket gen.Bracket) entitiesrooms.Bracket {
+	return entitiesrooms.Bracket{
+		ID:           bracket.ID.String(),
+		RoomID:       bracket.RoomID.String(),
+		Status:       bracket.Status,
+		MatchSeconds: int(bracket.MatchSeconds),
+		WinnerGameID: nullUUIDString(bracket.WinnerGameID),
+		CreatedBy:    bracket.CreatedBy.String(),
+		CreatedAt:    bracket.CreatedAt.Time,
+	}
+}
+
+func toMatchEntity(match gen.BracketMatch) entitiesrooms.BracketMatch {
+	return entitiesrooms.BracketMatch{
+		ID:           match.ID.String(),
+		BracketID:    match.BracketID.String(),
+		Round:        int(match.Round),
+		Position:     int(match.Position),
+		GameA:        match.GameA.String(),
+		GameB:        nullUUIDString(match.GameB),
+		Status:       match.Status,
+		WinnerGameID: nullUUIDString(match.WinnerGameID),
+		OpenedAt:     match.OpenedAt.Time,
+		ClosesAt:     match.ClosesAt.Time,
+	}
+}
+
+func nullUUIDString(id uuid.NullUUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return id.UUID.String()
+}
@@ -0,0 +1,8 @@
+-- name: Ensure :one
+INSERT INTO catalog_games (
+    id, title, min_players, max_players, duration_minutes, tags, link, created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT ((lower(title))) DO UPDATE SET title = catalog_games.title
+RETURNING *;
\ No newline at end of file
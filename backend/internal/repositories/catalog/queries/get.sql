@@ -0,0 +1,3 @@
+-- name: Get :one
+SELECT * FROM catalog_games
+WHERE id = $1;
\ No newline at end of file
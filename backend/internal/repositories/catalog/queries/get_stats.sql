@@ -0,0 +1,19 @@
+-- name: GetStats :one
+SELECT
+    (SELECT COUNT(DISTINCT g.room_id) FROM games g WHERE g.catalog_id = sqlc.arg(id)) AS rooms,
+    (
+        SELECT COALESCE(SUM(v.points), 0) FROM votes v JOIN games g ON g.id = v.game_id
+        WHERE g.catalog_id = sqlc.arg(id) AND v.kind = 'approve'
+    )::BIGINT AS votes,
+    (
+        SELECT COUNT(*) FROM votes v JOIN games g ON g.id = v.game_id
+        WHERE g.catalog_id = sqlc.arg(id) AND v.kind = 'veto'
+    ) AS vetoes,
+    (
+        SELECT COUNT(*) FROM random_results r JOIN games g ON g.id = r.game_id
+        WHERE g.catalog_id = sqlc.arg(id) AND r.rerolled_at IS NULL
+    ) AS picks,
+    (
+        SELECT MAX(r.created_at) FROM random_results r JOIN games g ON g.id = r.game_id
+        WHERE g.catalog_id = sqlc.arg(id) AND r.rerolled_at IS NULL
+    )::TIMESTAMPTZ AS last_picked_at;
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- name: Search :many
+SELECT * FROM catalog_games c
+WHERE sqlc.arg(query)::TEXT = ''
+   OR lower(c.title) LIKE sqlc.arg(prefix)::TEXT || '%'
+   OR c.title % sqlc.arg(query)::TEXT
+ORDER BY
+    (lower(c.title) LIKE sqlc.arg(prefix)::TEXT || '%') DESC,
+    similarity(c.title, sqlc.arg(query)::TEXT) DESC,
+    c.title
+LIMIT sqlc.arg(max_results)::INT;
\ No newline at end of file
@@ -0,0 +1,150 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	entitiescatalog "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/catalog"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/catalog/gen"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/google/uuid"
+)
+
+type CatalogRepository interface {
+	Ensure(context.Context, EnsureParams) (entitiescatalog.Game, error)
+	Get(context.Context, uuid.UUID) (entitiescatalog.Game, error)
+	Search(context.Context, SearchParams) ([]entitiescatalog.Game, error)
+	GetStats(context.Context, uuid.UUID) (entitiescatalog.Stats, error)
+}
+
+type Repository struct {
+	db *gen.Queries
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: gen.New(db)}
+}
+
+// EnsureParams - описание новой записи каталога; если запись с таким названием
+// без учёта регистра уже есть, возвращается она без изменений.
+type EnsureParams struct {
+	ID              uuid.UUID
+	Title           string
+	MinPlayers      int32
+	MaxPlayers      int32
+	DurationMinutes int32
+	Tags            []string
+	Link            string
+	CreatedBy       uuid.NullUUID
+}
+
+func (r *Repository) Ensure(ctx context.Context, params EnsureParams) (entitiescatalog.Game, error) {
+	tags := params.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	game, err := r.db.Ensure(ctx, gen.EnsureParams{
+		ID:              params.ID,
+		Title:           params.Title,
+		MinPlayers:      params.MinPlayers,
+		MaxPlayers:      params.MaxPlayers,
+		DurationMinutes: params.DurationMinutes,
+		Tags:            tags,
+		Link:            params.Link,
+		CreatedBy:       params.CreatedBy,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "EnsureCatalogGame error: %v; data: %v", err, params)
+
+		return entitiescatalog.Game{}, err
+	}
+
+	return toEntity(game), nil
+}
+
+func (r *Repository) Get(ctx context.Context, id uuid.UUID) (entitiescatalog.Game, error) {
+	game, err := r.db.Get(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiescatalog.Game{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "GetCatalogGame error: %v; id: %v", err, id)
+
+		return entitiescatalog.Game{}, err
+	}
+
+	return toEntity(game), nil
+}
+
+// SearchParams - Query ищется по триграммам, Prefix - название в нижнем регистре
+// с экранированными символами LIKE. Пустой Query возвращает каталог по алфавиту.
+type SearchParams struct {
+	Query      string
+	Prefix     string
+	MaxResults int32
+}
+
+// Search возвращает сначала игры, название которых начинается с запроса, затем похожие.
+func (r *Repository) Search(ctx context.Context, params SearchParams) ([]entitiescatalog.Game, error) {
+	items, err := r.db.Search(ctx, gen.SearchParams{
+		Query:      params.Query,
+		Prefix:     params.Prefix,
+		MaxResults: params.MaxResults,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "SearchCatalog error: %v; data: %v", err, params)
+
+		return nil, err
+	}
+
+	res := make([]entitiescatalog.Game, 0, len(items))
+	for _, it := range items {
+		res = append(res, toEntity(it))
+	}
+
+	return res, nil
+}
+
+func (r *Repository) GetStats(ctx context.Context, id uuid.UUID) (entitiescatalog.Stats, error) {
+	stats, err := r.db.GetStats(ctx, id)
+	if err != nil {
+		logger.Errorf(ctx, "GetCatalogStats error: %v; id: %v", err, id)
+
+		return entitiescatalog.Stats{}, err
+	}
+
+	return entitiescatalog.Stats{
+		Rooms:        int(stats.Rooms),
+		Votes:        int(stats.Votes),
+		Vetoes:       int(stats.Vetoes),
+		Picks:        int(stats.Picks),
+		LastPickedAt: stats.LastPickedAt.Time,
+	}, nil
+}
+
+func toEntity(game gen.CatalogGame) entitiescatalog.Game {
+	tags := game.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	var createdBy string
+	if game.CreatedBy.Valid {
+		createdBy = game.CreatedBy.UUID.String()
+	}
+
+	return entitiescatalog.Game{
+		ID:              game.ID.String(),
+		Title:           game.Title,
+		MinPlayers:      int(game.MinPlayers),
+		MaxPlayers:      int(game.MaxPlayers),
+		DurationMinutes: int(game.DurationMinutes),
+		Tags:            tags,
+		Link:            game.Link,
+		CreatedBy:       createdBy,
+		CreatedAt:       game.CreatedAt.Time,
+	}
+}
@@ -1,6 +1,6 @@
 -- name: Add :one
 INSERT INTO GAMES (
-    id, room_id, title
+    id, room_id, title, min_players, max_players, duration_minutes, tags, link, notes, catalog_id, brought_by
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 ) RETURNING *;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- name: Archive :one
+UPDATE GAMES
+SET archived_at = NOW()
+WHERE id = $1 AND archived_at IS NULL
+RETURNING *;
\ No newline at end of file
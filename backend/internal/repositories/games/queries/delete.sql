@@ -1,3 +0,0 @@
--- name: Delete :exec
-DELETE FROM GAMES
-WHERE id = $1;
\ No newline at end of file
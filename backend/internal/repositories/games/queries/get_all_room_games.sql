@@ -1,3 +1,3 @@
 -- name: GetAllRoomGames :many
 SELECT * FROM GAMES
-WHERE room_id = $1;
\ No newline at end of file
+WHERE room_id = $1 AND archived_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: GetArchived :many
+SELECT * FROM GAMES
+WHERE room_id = $1 AND archived_at IS NOT NULL AND merged_into IS NULL
+ORDER BY archived_at DESC;
\ No newline at end of file
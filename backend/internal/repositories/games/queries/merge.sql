@@ -0,0 +1,41 @@
+-- name: DeleteConflictingVotes :exec
+-- голос за исходную игру удаляется, если в том же раунде участник голосовал и за целевую
+DELETE FROM votes s
+WHERE s.game_id = sqlc.arg(source_id)
+  AND EXISTS (
+    SELECT 1 FROM votes t
+    WHERE t.game_id = sqlc.arg(target_id) AND t.poll_id = s.poll_id AND t.user_id = s.user_id
+  );
+
+-- name: MoveVotes :exec
+UPDATE votes
+SET game_id = sqlc.arg(target_id)
+WHERE game_id = sqlc.arg(source_id);
+
+-- name: MoveResults :exec
+UPDATE random_results
+SET game_id = sqlc.arg(target_id)
+WHERE game_id = sqlc.arg(source_id);
+
+-- name: MoveBallots :exec
+UPDATE ballots
+SET rankings = CASE
+    WHEN sqlc.arg(target_id)::UUID = ANY(rankings) THEN array_remove(rankings, sqlc.arg(source_id)::UUID)
+    ELSE array_replace(rankings, sqlc.arg(source_id)::UUID, sqlc.arg(target_id)::UUID)
+END
+WHERE room_id = sqlc.arg(room_id) AND sqlc.arg(source_id)::UUID = ANY(rankings);
+
+-- name: MovePollCandidates :exec
+UPDATE polls
+SET candidates = CASE
+    WHEN sqlc.arg(target_id)::UUID = ANY(candidates) THEN array_remove(candidates, sqlc.arg(source_id)::UUID)
+    ELSE array_replace(candidates, sqlc.arg(source_id)::UUID, sqlc.arg(target_id)::UUID)
+END
+WHERE room_id = sqlc.arg(room_id) AND sqlc.arg(source_id)::UUID = ANY(candidates);
+
+-- name: MarkMerged :exec
+-- исходная игра и игры, слитые в неё раньше, ссылаются на целевую, поэтому ID из снимков разрешаются за один шаг
+UPDATE games
+SET merged_into = sqlc.arg(target_id),
+    archived_at = COALESCE(archived_at, NOW())
+WHERE id = sqlc.arg(source_id) OR merged_into = sqlc.arg(source_id);
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- name: Restore :one
+UPDATE GAMES
+SET archived_at = NULL
+WHERE id = $1 AND archived_at IS NOT NULL AND merged_into IS NULL
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- name: Update :one
+UPDATE GAMES
+SET
+    title = $2,
+    min_players = $3,
+    max_players = $4,
+    duration_minutes = $5,
+    tags = $6,
+    link = $7,
+    notes = $8,
+    brought_by = $9
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
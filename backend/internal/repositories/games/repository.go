@@ -13,30 +13,52 @@ import (
 
 type GameRepository interface {
 	Add(context.Context, AddParams) (entitiesrooms.Game, error)
+	AddMany(context.Context, []AddParams) ([]entitiesrooms.Game, error)
 	GetAllRoomGames(context.Context, uuid.UUID) ([]entitiesrooms.Game, error)
-	Delete(context.Context, uuid.UUID) error
+	Archive(context.Context, uuid.UUID) (entitiesrooms.Game, error)
+	Restore(context.Context, uuid.UUID) (entitiesrooms.Game, error)
+	GetArchived(context.Context, uuid.UUID) ([]entitiesrooms.Game, error)
 	Get(context.Context, uuid.UUID) (entitiesrooms.Game, error)
+	Update(context.Context, UpdateParams) (entitiesrooms.Game, error)
+	Merge(context.Context, MergeParams) error
 }
 
 type Repository struct {
-	db *gen.Queries
+	conn *sql.DB
+	db   *gen.Queries
 }
 
 func NewRepository(db *sql.DB) *Repository {
-	return &Repository{db: gen.New(db)}
+	return &Repository{conn: db, db: gen.New(db)}
 }
 
 type AddParams struct {
-	ID     uuid.UUID
-	RoomID uuid.UUID
-	Title  string
+	ID              uuid.UUID
+	RoomID          uuid.UUID
+	Title           string
+	MinPlayers      int32
+	MaxPlayers      int32
+	DurationMinutes int32
+	Tags            []string
+	Link            string
+	Notes           string
+	CatalogID       uuid.NullUUID
+	BroughtBy       uuid.NullUUID
 }
 
 func (r *Repository) Add(ctx context.Context, params AddParams) (entitiesrooms.Game, error) {
 	created, err := r.db.Add(ctx, gen.AddParams{
-		ID:     params.ID,
-		RoomID: params.RoomID,
-		Title:  params.Title,
+		ID:              params.ID,
+		RoomID:          params.RoomID,
+		Title:           params.Title,
+		MinPlayers:      params.MinPlayers,
+		MaxPlayers:      params.MaxPlayers,
+		DurationMinutes: params.DurationMinutes,
+		Tags:            params.Tags,
+		Link:            params.Link,
+		Notes:           params.Notes,
+		CatalogID:       params.CatalogID,
+		BroughtBy:       params.BroughtBy,
 	})
 	if err != nil {
 		logger.Errorf(ctx, "AddGame error: %v; data: %v", err, params)
@@ -44,12 +66,50 @@ func (r *Repository) Add(ctx context.Context, params AddParams) (entitiesrooms.G
 		return entitiesrooms.Game{}, err
 	}
 
-	return entitiesrooms.Game{
-		ID:        created.ID.String(),
-		RoomID:    created.RoomID.String(),
-		Title:     created.Title,
-		CreatedAt: created.CreatedAt.Time,
-	}, nil
+	return toEntity(created), nil
+}
+
+// AddMany добавляет игры в одной транзакции: если хотя бы одна не добавилась, не добавляется ни одна.
+func (r *Repository) AddMany(ctx context.Context, params []AddParams) ([]entitiesrooms.Game, error) {
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Errorf(ctx, "AddGames BeginTx error: %v", err)
+
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q := r.db.WithTx(tx)
+	res := make([]entitiesrooms.Game, 0, len(params))
+	for _, p := range params {
+		created, err := q.Add(ctx, gen.AddParams{
+			ID:              p.ID,
+			RoomID:          p.RoomID,
+			Title:           p.Title,
+			MinPlayers:      p.MinPlayers,
+			MaxPlayers:      p.MaxPlayers,
+			DurationMinutes: p.DurationMinutes,
+			Tags:            p.Tags,
+			Link:            p.Link,
+			Notes:           p.Notes,
+			CatalogID:       p.CatalogID,
+			BroughtBy:       p.BroughtBy,
+		})
+		if err != nil {
+			logger.Errorf(ctx, "AddGames Add error: %v; data: %v", err, p)
+
+			return nil, err
+		}
+		res = append(res, toEntity(created))
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Errorf(ctx, "AddGames Commit error: %v", err)
+
+		return nil, err
+	}
+
+	return res, nil
 }
 
 func (r *Repository) GetAllRoomGames(ctx context.Context, roomID uuid.UUID) ([]entitiesrooms.Game, error) {
@@ -62,24 +122,59 @@ func (r *Repository) GetAllRoomGames(ctx context.Context, roomID uuid.UUID) ([]e
 
 	res := make([]entitiesrooms.Game, 0, len(items))
 	for _, it := range items {
-		res = append(res, entitiesrooms.Game{
-			ID:        it.ID.String(),
-			RoomID:    it.RoomID.String(),
-			Title:     it.Title,
-			CreatedAt: it.CreatedAt.Time,
-		})
+		res = append(res, toEntity(it))
 	}
 
 	return res, nil
 }
 
-func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
-	err := r.db.Delete(ctx, id)
+// Archive убирает игру в архив. Если игры нет или она уже в архиве, возвращает пустую игру.
+func (r *Repository) Archive(ctx context.Context, id uuid.UUID) (entitiesrooms.Game, error) {
+	archived, err := r.db.Archive(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.Game{}, nil
+	}
+
 	if err != nil {
-		logger.Errorf(ctx, "DeleteGame error: %v; id: %v", err, id)
-		return err
+		logger.Errorf(ctx, "ArchiveGame error: %v; id: %v", err, id)
+
+		return entitiesrooms.Game{}, err
 	}
-	return nil
+
+	return toEntity(archived), nil
+}
+
+// Restore возвращает игру из архива. Если игры нет или она не в архиве, возвращает пустую игру.
+func (r *Repository) Restore(ctx context.Context, id uuid.UUID) (entitiesrooms.Game, error) {
+	restored, err := r.db.Restore(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.Game{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "RestoreGame error: %v; id: %v", err, id)
+
+		return entitiesrooms.Game{}, err
+	}
+
+	return toEntity(restored), nil
+}
+
+// GetArchived возвращает архивные игры комнаты, начиная с последней убранной в архив.
+func (r *Repository) GetArchived(ctx context.Context, roomID uuid.UUID) ([]entitiesrooms.Game, error) {
+	items, err := r.db.GetArchived(ctx, roomID)
+	if err != nil {
+		logger.Errorf(ctx, "GetArchivedGames error: %v; roomID: %v", err, roomID)
+
+		return nil, err
+	}
+
+	res := make([]entitiesrooms.Game, 0, len(items))
+	for _, it := range items {
+		res = append(res, toEntity(it))
+	}
+
+	return res, nil
 }
 
 func (r *Repository) Get(ctx context.Context, id uuid.UUID) (entitiesrooms.Game, error) {
@@ -93,10 +188,136 @@ func (r *Repository) Get(ctx context.Context, id uuid.UUID) (entitiesrooms.Game,
 		return entitiesrooms.Game{}, err
 	}
 
+	return toEntity(item), nil
+}
+
+// UpdateParams - все поля игры перезаписываются.
+type UpdateParams struct {
+	ID              uuid.UUID
+	Title           string
+	MinPlayers      int32
+	MaxPlayers      int32
+	DurationMinutes int32
+	Tags            []string
+	Link            string
+	Notes           string
+	BroughtBy       uuid.NullUUID
+}
+
+func (r *Repository) Update(ctx context.Context, params UpdateParams) (entitiesrooms.Game, error) {
+	updated, err := r.db.Update(ctx, gen.UpdateParams{
+		ID:              params.ID,
+		Title:           params.Title,
+		MinPlayers:      params.MinPlayers,
+		MaxPlayers:      params.MaxPlayers,
+		DurationMinutes: params.DurationMinutes,
+		Tags:            params.Tags,
+		Link:            params.Link,
+		Notes:           params.Notes,
+		BroughtBy:       params.BroughtBy,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.Game{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "UpdateGame error: %v; data: %v", err, params)
+		return entitiesrooms.Game{}, err
+	}
+
+	return toEntity(updated), nil
+}
+
+// MergeParams - голоса и история игры SourceID переносятся на игру TargetID той же комнаты.
+type MergeParams struct {
+	RoomID   uuid.UUID
+	SourceID uuid.UUID
+	TargetID uuid.UUID
+}
+
+// Merge в одной транзакции переносит на целевую игру голоса, результаты выбора,
+// места в бюллетенях и дополнительных раундах исходной игры, после чего убирает исходную игру
+// в архив со ссылкой на целевую. Снимки розыгрышей не меняются: ID из них разрешаются при чтении.
+// Если участник в одном раунде голосовал за обе игры, остаётся голос за целевую.
+func (r *Repository) Merge(ctx context.Context, params MergeParams) error {
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Errorf(ctx, "MergeGames BeginTx error: %v", err)
+
+		return err
+	}
+	defer tx.Rollback()
+
+	q := r.db.WithTx(tx)
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"DeleteConflictingVotes", func() error {
+			return q.DeleteConflictingVotes(ctx, gen.DeleteConflictingVotesParams{SourceID: params.SourceID, TargetID: params.TargetID})
+		}},
+		{"MoveVotes", func() error {
+			return q.MoveVotes(ctx, gen.MoveVotesParams{SourceID: params.SourceID, TargetID: params.TargetID})
+		}},
+		{"MoveResults", func() error {
+			return q.MoveResults(ctx, gen.MoveResultsParams{SourceID: params.SourceID, TargetID: params.TargetID})
+		}},
+		{"MoveBallots", func() error {
+			return q.MoveBallots(ctx, gen.MoveBallotsParams{RoomID: params.RoomID, SourceID: params.SourceID, TargetID: params.TargetID})
+		}},
+		{"MovePollCandidates", func() error {
+			return q.MovePollCandidates(ctx, gen.MovePollCandidatesParams{RoomID: params.RoomID, SourceID: params.SourceID, TargetID: params.TargetID})
+		}},
+		{"MarkMerged", func() error {
+			return q.MarkMerged(ctx, gen.MarkMergedParams{SourceID: params.SourceID, TargetID: params.TargetID})
+		}},
+	}
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			logger.Errorf(ctx, "MergeGames %s error: %v; data: %v", step.name, err, params)
+
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Errorf(ctx, "MergeGames Commit error: %v", err)
+
+		return err
+	}
+
+	return nil
+}
+
+func toEntity(game gen.Game) entitiesrooms.Game {
+	tags := game.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	var catalogID string
+	if game.CatalogID.Valid {
+		catalogID = game.CatalogID.UUID.String()
+	}
+
+	var broughtBy string
+	if game.BroughtBy.Valid {
+		broughtBy = game.BroughtBy.UUID.String()
+	}
+
 	return entitiesrooms.Game{
-		ID:        item.ID.String(),
-		RoomID:    item.RoomID.String(),
-		Title:     item.Title,
-		CreatedAt: item.CreatedAt.Time,
-	}, nil
+		ID:              game.ID.String(),
+		RoomID:          game.RoomID.String(),
+		Title:           game.Title,
+		MinPlayers:      int(game.MinPlayers),
+		MaxPlayers:      int(game.MaxPlayers),
+		DurationMinutes: int(game.DurationMinutes),
+		Tags:            tags,
+		Link:            game.Link,
+		Notes:           game.Notes,
+		CatalogID:       catalogID,
+		BroughtBy:       broughtBy,
+		ArchivedAt:      game.ArchivedAt.Time,
+		CreatedAt:       game.CreatedAt.Time,
+	}
 }
@@ -0,0 +1,6 @@
+-- name: Add :one
+INSERT INTO library_games (
+    id, user_id, catalog_id, title, min_players, max_players, duration_minutes, tags, link, notes
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- name: Delete :exec
+DELETE FROM library_games
+WHERE id = $1 AND user_id = $2;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: GetAll :many
+SELECT * FROM library_games
+WHERE user_id = $1
+ORDER BY lower(title);
\ No newline at end of file
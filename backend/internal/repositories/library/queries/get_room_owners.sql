@@ -0,0 +1,9 @@
+-- name: GetRoomOwners :many
+-- участники комнаты, в библиотеке которых есть игра каталога, к которой относится игра комнаты
+SELECT g.id AS game_id, u.id AS user_id, u.name AS user_name, u.created_at AS user_created_at
+FROM games g
+JOIN library_games l ON l.catalog_id = g.catalog_id
+JOIN room_participants p ON p.room_id = g.room_id AND p.user_id = l.user_id
+JOIN users u ON u.id = l.user_id
+WHERE g.room_id = $1 AND g.archived_at IS NULL
+ORDER BY g.id, u.name;
\ No newline at end of file
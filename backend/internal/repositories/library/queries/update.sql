@@ -0,0 +1,12 @@
+-- name: Update :one
+UPDATE library_games
+SET
+    title = $2,
+    min_players = $3,
+    max_players = $4,
+    duration_minutes = $5,
+    tags = $6,
+    link = $7,
+    notes = $8
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
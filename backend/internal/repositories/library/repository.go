@@ -0,0 +1,191 @@
+package library
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/profile"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/library/gen"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/google/uuid"
+)
+
+type LibraryRepository interface {
+	Add(context.Context, AddParams) (profile.LibraryGame, error)
+	Get(context.Context, uuid.UUID) (profile.LibraryGame, error)
+	GetAll(context.Context, uuid.UUID) ([]profile.LibraryGame, error)
+	Update(context.Context, UpdateParams) (profile.LibraryGame, error)
+	Delete(context.Context, uuid.UUID, uuid.UUID) error
+	GetRoomOwners(context.Context, uuid.UUID) ([]profile.GameOwners, error)
+}
+
+type Repository struct {
+	db *gen.Queries
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: gen.New(db)}
+}
+
+type AddParams struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	CatalogID       uuid.NullUUID
+	Title           string
+	MinPlayers      int32
+	MaxPlayers      int32
+	DurationMinutes int32
+	Tags            []string
+	Link            string
+	Notes           string
+}
+
+func (r *Repository) Add(ctx context.Context, params AddParams) (profile.LibraryGame, error) {
+	created, err := r.db.Add(ctx, gen.AddParams{
+		ID:              params.ID,
+		UserID:          params.UserID,
+		CatalogID:       params.CatalogID,
+		Title:           params.Title,
+		MinPlayers:      params.MinPlayers,
+		MaxPlayers:      params.MaxPlayers,
+		DurationMinutes: params.DurationMinutes,
+		Tags:            params.Tags,
+		Link:            params.Link,
+		Notes:           params.Notes,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "AddLibraryGame error: %v; data: %v", err, params)
+
+		return profile.LibraryGame{}, err
+	}
+
+	return toEntity(created), nil
+}
+
+func (r *Repository) Get(ctx context.Context, id uuid.UUID) (profile.LibraryGame, error) {
+	item, err := r.db.Get(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return profile.LibraryGame{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "GetLibraryGame error: %v; id: %v", err, id)
+		return profile.LibraryGame{}, err
+	}
+
+	return toEntity(item), nil
+}
+
+func (r *Repository) GetAll(ctx context.Context, userID uuid.UUID) ([]profile.LibraryGame, error) {
+	items, err := r.db.GetAll(ctx, userID)
+	if err != nil {
+		logger.Errorf(ctx, "GetLibrary error: %v; userID: %v", err, userID)
+
+		return nil, err
+	}
+
+	res := make([]profile.LibraryGame, 0, len(items))
+	for _, it := range items {
+		res = append(res, toEntity(it))
+	}
+
+	return res, nil
+}
+
+// UpdateParams - все поля игры перезаписываются.
+type UpdateParams struct {
+	ID              uuid.UUID
+	Title           string
+	MinPlayers      int32
+	MaxPlayers      int32
+	DurationMinutes int32
+	Tags            []string
+	Link            string
+	Notes           string
+}
+
+func (r *Repository) Update(ctx context.Context, params UpdateParams) (profile.LibraryGame, error) {
+	updated, err := r.db.Update(ctx, gen.UpdateParams{
+		ID:              params.ID,
+		Title:           params.Title,
+		MinPlayers:      params.MinPlayers,
+		MaxPlayers:      params.MaxPlayers,
+		DurationMinutes: params.DurationMinutes,
+		Tags:            params.Tags,
+		Link:            params.Link,
+		Notes:           params.Notes,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return profile.LibraryGame{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "UpdateLibraryGame error: %v; data: %v", err, params)
+		return profile.LibraryGame{}, err
+	}
+
+	return toEntity(updated), nil
+}
+
+func (r *Repository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	err := r.db.Delete(ctx, gen.DeleteParams{ID: id, UserID: userID})
+	if err != nil {
+		logger.Errorf(ctx, "DeleteLibraryGame error: %v; id: %v", err, id)
+		return err
+	}
+	return nil
+}
+
+// GetRoomOwners возвращает для игр комнаты участников, у которых они есть в библиотеке.
+// Игры без владельцев не возвращаются.
+func (r *Repository) GetRoomOwners(ctx context.Context, roomID uuid.UUID) ([]profile.GameOwners, error) {
+	items, err := r.db.GetRoomOwners(ctx, roomID)
+	if err != nil {
+		logger.Errorf(ctx, "GetRoomOwners error: %v; roomID: %v", err, roomID)
+
+		return nil, err
+	}
+
+	var res []profile.GameOwners
+	for _, it := range items {
+		gameID := it.GameID.String()
+		if len(res) == 0 || res[len(res)-1].GameID != gameID {
+			res = append(res, profile.GameOwners{GameID: gameID})
+		}
+		last := &res[len(res)-1]
+		last.Owners = append(last.Owners, profile.User{
+			ID:        it.UserID.String(),
+			Name:      it.UserName,
+			CreatedAt: it.UserCreatedAt.Time,
+		})
+	}
+
+	return res, nil
+}
+
+func toEntity(game gen.LibraryGame) profile.LibraryGame {
+	tags := game.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	var catalogID string
+	if game.CatalogID.Valid {
+		catalogID = game.CatalogID.UUID.String()
+	}
+
+	return profile.LibraryGame{
+		ID:              game.ID.String(),
+		UserID:          game.UserID.String(),
+		CatalogID:       catalogID,
+		Title:           game.Title,
+		MinPlayers:      int(game.MinPlayers),
+		MaxPlayers:      int(game.MaxPlayers),
+		DurationMinutes: int(game.DurationMinutes),
+		Tags:            tags,
+		Link:            game.Link,
+		Notes:           game.Notes,
+		CreatedAt:       game.CreatedAt.Time,
+	}
+}
@@ -1,5 +1,5 @@
 -- name: GetAllParticipants :many
-SELECT u.id, u.name, rp.role
+SELECT u.id, u.name, rp.role, rp.ready, rp.present
 FROM room_participants rp
 JOIN users u ON rp.user_id = u.id
 WHERE rp.room_id = $1;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: ResetPresent :exec
+UPDATE room_participants
+SET present = FALSE
+WHERE room_id = $1;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: ResetReady :exec
+UPDATE room_participants
+SET ready = FALSE
+WHERE room_id = $1;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- name: SetPresent :one
+UPDATE room_participants
+SET present = $3
+WHERE room_id = $1 AND user_id = $2
+RETURNING *;
\ No newline at end of file
@@ -17,11 +17,17 @@ type ParticipantRepository interface {
 	GetAllParticipants(context.Context, uuid.UUID) ([]ParticipantWithUser, error)
 	Delete(context.Context, uuid.UUID, uuid.UUID) error
 	Get(context.Context, uuid.UUID, uuid.UUID) (entitiesrooms.RoomParticipant, error)
+	SetReady(context.Context, SetReadyParams) (entitiesrooms.RoomParticipant, error)
+	SetPresent(context.Context, SetPresentParams) (entitiesrooms.RoomParticipant, error)
+	ResetReady(context.Context, uuid.UUID) error
+	ResetPresent(context.Context, uuid.UUID) error
 }
 
 type ParticipantWithUser struct {
-	User profile.User `json:"user"`
-	Role string       `json:"role"`
+	User    profile.User `json:"user"`
+	Role    string       `json:"role"`
+	Ready   bool         `json:"ready"`
+	Present bool         `json:"present"`
 }
 
 type Repository struct {
@@ -52,13 +58,7 @@ func (r *Repository) Add(ctx context.Context, params AddParams) (entitiesrooms.R
 		return entitiesrooms.RoomParticipant{}, err
 	}
 
-	return entitiesrooms.RoomParticipant{
-		ID:        created.ID.String(),
-		RoomID:    created.RoomID.String(),
-		UserID:    created.UserID.String(),
-		Role:      created.Role,
-		CreatedAt: created.CreatedAt.Time,
-	}, nil
+	return toEntity(created), nil
 }
 
 func (r *Repository) GetAllParticipants(ctx context.Context, roomID uuid.UUID) ([]ParticipantWithUser, error) {
@@ -76,7 +76,9 @@ func (r *Repository) GetAllParticipants(ctx context.Context, roomID uuid.UUID) (
 				ID:   it.ID.String(),
 				Name: it.Name,
 			},
-			Role: it.Role,
+			Role:    it.Role,
+			Ready:   it.Ready,
+			Present: it.Present,
 		})
 	}
 
@@ -112,11 +114,93 @@ func (r *Repository) Get(ctx context.Context, roomID, userID uuid.UUID) (entitie
 		return entitiesrooms.RoomParticipant{}, err
 	}
 
+	return toEntity(participant), nil
+}
+
+type SetReadyParams struct {
+	RoomID uuid.UUID
+	UserID uuid.UUID
+	Ready  bool
+}
+
+// SetReady отмечает готовность участника. Если пользователь не участник комнаты, возвращает пустого участника.
+func (r *Repository) SetReady(ctx context.Context, params SetReadyParams) (entitiesrooms.RoomParticipant, error) {
+	participant, err := r.db.SetReady(ctx, gen.SetReadyParams{
+		RoomID: params.RoomID,
+		UserID: params.UserID,
+		Ready:  params.Ready,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.RoomParticipant{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "SetParticipantReady error: %v; data: %v", err, params)
+
+		return entitiesrooms.RoomParticipant{}, err
+	}
+
+	return toEntity(participant), nil
+}
+
+type SetPresentParams struct {
+	RoomID  uuid.UUID
+	UserID  uuid.UUID
+	Present bool
+}
+
+// SetPresent отмечает присутствие участника. Если пользователь не участник комнаты, возвращает пустого участника.
+func (r *Repository) SetPresent(ctx context.Context, params SetPresentParams) (entitiesrooms.RoomParticipant, error) {
+	participant, err := r.db.SetPresent(ctx, gen.SetPresentParams{
+		RoomID:  params.RoomID,
+		UserID:  params.UserID,
+		Present: params.Present,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.RoomParticipant{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "SetParticipantPresent error: %v; data: %v", err, params)
+
+		return entitiesrooms.RoomParticipant{}, err
+	}
+
+	return toEntity(participant), nil
+}
+
+// ResetReady снимает готовность со всех участников комнаты.
+func (r *Repository) ResetReady(ctx context.Context, roomID uuid.UUID) error {
+	err := r.db.ResetReady(ctx, roomID)
+	if err != nil {
+		logger.Errorf(ctx, "ResetParticipantsReady error: %v; roomID: %v", err, roomID)
+
+		return err
+	}
+
+	return nil
+}
+
+// ResetPresent снимает отметки присутствия со всех участников комнаты.
+func (r *Repository) ResetPresent(ctx context.Context, roomID uuid.UUID) error {
+	err := r.db.ResetPresent(ctx, roomID)
+	if err != nil {
+		logger.Errorf(ctx, "ResetParticipantsPresent error: %v; roomID: %v", err, roomID)
+
+		return err
+	}
+
+	return nil
+}
+
+func toEntity(participant gen.RoomParticipant) entitiesrooms.RoomParticipant {
 	return entitiesrooms.RoomParticipant{
 		ID:        participant.ID.String(),
 		RoomID:    participant.RoomID.String(),
 		UserID:    participant.UserID.String(),
 		Role:      participant.Role,
+		Ready:     participant.Ready,
+		Present:   participant.Present,
 		CreatedAt: participant.CreatedAt.Time,
-	}, nil
+	}
 }
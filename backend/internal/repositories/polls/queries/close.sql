@@ -0,0 +1,5 @@
+-- name: Close :one
+UPDATE polls
+SET status = 'closed', closed_at = NOW()
+WHERE id = $1 AND status = 'open'
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- name: Create :one
+INSERT INTO polls (
+    id, room_id, opened_by, runoff_of, candidates, deadline
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
\ No newline at end of file
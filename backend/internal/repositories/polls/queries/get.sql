@@ -0,0 +1,3 @@
+-- name: Get :one
+SELECT * FROM polls
+WHERE id = $1 AND room_id = $2;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: GetAll :many
+SELECT * FROM polls
+WHERE room_id = $1
+ORDER BY created_at;
\ No newline at end of file
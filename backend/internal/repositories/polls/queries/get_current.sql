@@ -0,0 +1,5 @@
+-- name: GetCurrent :one
+SELECT * FROM polls
+WHERE room_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: GetPending :many
+SELECT * FROM polls
+WHERE status = 'open' AND deadline IS NOT NULL
+ORDER BY deadline;
\ No newline at end of file
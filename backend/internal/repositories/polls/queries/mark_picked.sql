@@ -0,0 +1,5 @@
+-- name: MarkPicked :one
+UPDATE polls
+SET status = 'picked', closed_at = COALESCE(closed_at, NOW())
+WHERE id = $1 AND status <> 'picked'
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- name: SetDeadline :one
+UPDATE polls
+SET deadline = $2
+WHERE id = $1 AND status = 'open'
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,209 @@
+package polls
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/polls/gen"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/google/uuid"
+)
+
+type PollRepository interface {
+	Create(context.Context, CreateParams) (entitiesrooms.Poll, error)
+	Get(context.Context, uuid.UUID, uuid.UUID) (entitiesrooms.Poll, error)
+	GetCurrent(context.Context, uuid.UUID) (entitiesrooms.Poll, error)
+	GetAll(context.Context, uuid.UUID) ([]entitiesrooms.Poll, error)
+	Close(context.Context, uuid.UUID) (entitiesrooms.Poll, error)
+	MarkPicked(context.Context, uuid.UUID) (entitiesrooms.Poll, error)
+	SetDeadline(context.Context, uuid.UUID, time.Time) (entitiesrooms.Poll, error)
+	GetPending(context.Context) ([]entitiesrooms.Poll, error)
+}
+
+type Repository struct {
+	db *gen.Queries
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: gen.New(db)}
+}
+
+// CreateParams - для дополнительного раунда RunoffOf указывает исходный раунд,
+// а Candidates - игры, между которыми идёт голосование. Нулевой Deadline - раунд без срока.
+type CreateParams struct {
+	ID         uuid.UUID
+	RoomID     uuid.UUID
+	OpenedBy   uuid.UUID
+	RunoffOf   uuid.NullUUID
+	Candidates []uuid.UUID
+	Deadline   time.Time
+}
+
+func (r *Repository) Create(ctx context.Context, params CreateParams) (entitiesrooms.Poll, error) {
+	candidates := params.Candidates
+	if candidates == nil {
+		candidates = []uuid.UUID{}
+	}
+
+	poll, err := r.db.Create(ctx, gen.CreateParams{
+		ID:         params.ID,
+		RoomID:     params.RoomID,
+		OpenedBy:   params.OpenedBy,
+		RunoffOf:   params.RunoffOf,
+		Candidates: candidates,
+		Deadline:   sql.NullTime{Time: params.Deadline, Valid: !params.Deadline.IsZero()},
+	})
+	if err != nil {
+		logger.Errorf(ctx, "CreatePoll error: %v; data: %v", err, params)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	return toEntity(poll), nil
+}
+
+func (r *Repository) Get(ctx context.Context, id, roomID uuid.UUID) (entitiesrooms.Poll, error) {
+	poll, err := r.db.Get(ctx, gen.GetParams{
+		ID:     id,
+		RoomID: roomID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.Poll{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "GetPoll error: %v; id: %v", err, id)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	return toEntity(poll), nil
+}
+
+// GetCurrent возвращает последний раунд комнаты. Если раундов нет, возвращает пустой раунд.
+func (r *Repository) GetCurrent(ctx context.Context, roomID uuid.UUID) (entitiesrooms.Poll, error) {
+	poll, err := r.db.GetCurrent(ctx, roomID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.Poll{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "GetCurrentPoll error: %v; roomID: %v", err, roomID)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	return toEntity(poll), nil
+}
+
+func (r *Repository) GetAll(ctx context.Context, roomID uuid.UUID) ([]entitiesrooms.Poll, error) {
+	items, err := r.db.GetAll(ctx, roomID)
+	if err != nil {
+		logger.Errorf(ctx, "GetAllPolls error: %v; roomID: %v", err, roomID)
+
+		return nil, err
+	}
+
+	res := make([]entitiesrooms.Poll, 0, len(items))
+	for _, it := range items {
+		res = append(res, toEntity(it))
+	}
+
+	return res, nil
+}
+
+// Close закрывает открытый раунд. Если раунд уже закрыт, возвращает пустой раунд.
+func (r *Repository) Close(ctx context.Context, id uuid.UUID) (entitiesrooms.Poll, error) {
+	poll, err := r.db.Close(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.Poll{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "ClosePoll error: %v; id: %v", err, id)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	return toEntity(poll), nil
+}
+
+// MarkPicked отмечает, что по раунду выбрана игра. Если это уже отмечено, возвращает пустой раунд.
+func (r *Repository) MarkPicked(ctx context.Context, id uuid.UUID) (entitiesrooms.Poll, error) {
+	poll, err := r.db.MarkPicked(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.Poll{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "MarkPollPicked error: %v; id: %v", err, id)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	return toEntity(poll), nil
+}
+
+// SetDeadline задаёт срок голосования открытого раунда; нулевое время снимает срок.
+// Если раунд уже закрыт, возвращает пустой раунд.
+func (r *Repository) SetDeadline(ctx context.Context, id uuid.UUID, deadline time.Time) (entitiesrooms.Poll, error) {
+	poll, err := r.db.SetDeadline(ctx, gen.SetDeadlineParams{
+		ID:       id,
+		Deadline: sql.NullTime{Time: deadline, Valid: !deadline.IsZero()},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.Poll{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "SetPollDeadline error: %v; id: %v", err, id)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	return toEntity(poll), nil
+}
+
+// GetPending возвращает открытые раунды со сроком голосования во всех комнатах.
+func (r *Repository) GetPending(ctx context.Context) ([]entitiesrooms.Poll, error) {
+	items, err := r.db.GetPending(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "GetPendingPolls error: %v", err)
+
+		return nil, err
+	}
+
+	res := make([]entitiesrooms.Poll, 0, len(items))
+	for _, it := range items {
+		res = append(res, toEntity(it))
+	}
+
+	return res, nil
+}
+
+func toEntity(poll gen.Poll) entitiesrooms.Poll {
+	var runoffOf string
+	if poll.RunoffOf.Valid {
+		runoffOf = poll.RunoffOf.UUID.String()
+	}
+
+	candidates := make([]string, 0, len(poll.Candidates))
+	for _, id := range poll.Candidates {
+		candidates = append(candidates, id.String())
+	}
+
+	return entitiesrooms.Poll{
+		ID:         poll.ID.String(),
+		RoomID:     poll.RoomID.String(),
+		Status:     poll.Status,
+		OpenedBy:   poll.OpenedBy.String(),
+		CreatedAt:  poll.CreatedAt.Time,
+		ClosedAt:   poll.ClosedAt.Time,
+		Deadline:   poll.Deadline.Time,
+		RunoffOf:   runoffOf,
+		Candidates: candidates,
+	}
+}
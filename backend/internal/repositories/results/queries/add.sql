@@ -1,7 +1,7 @@
 -- name: Add :one
 INSERT INTO random_results (
-    id, room_id, game_id, chosen_by
+    id, room_id, game_id, chosen_by, strategy, seed, commitment, snapshot, options, batch_id, position, poll_id, reroll_of, lands_at
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 )
 RETURNING *;
\ No newline at end of file
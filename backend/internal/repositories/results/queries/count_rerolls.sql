@@ -0,0 +1,4 @@
+-- name: CountRerolls :one
+SELECT COUNT(*) FROM random_results
+WHERE room_id = sqlc.arg(room_id)
+  AND rerolled_at > NOW() - make_interval(hours => sqlc.arg(hours)::INT);
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- name: DeleteBatch :exec
+DELETE FROM random_results
+WHERE room_id = $1 AND batch_id = $2 AND revealed_at IS NULL;
\ No newline at end of file
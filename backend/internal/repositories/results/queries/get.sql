@@ -0,0 +1,3 @@
+-- name: GetResult :one
+SELECT * FROM random_results
+WHERE id = $1 AND room_id = $2 AND revealed_at IS NOT NULL;
\ No newline at end of file
@@ -1,3 +1,7 @@
 -- name: GetAllResults :many
-SELECT * FROM random_results
-WHERE room_id = $1;
\ No newline at end of file
+-- название игры берётся и для архивных игр, чтобы история оставалась читаемой
+SELECT sqlc.embed(r), g.title AS game_title, (g.archived_at IS NOT NULL)::BOOLEAN AS game_archived
+FROM random_results r
+JOIN games g ON g.id = r.game_id
+WHERE r.room_id = $1 AND r.revealed_at IS NOT NULL
+ORDER BY r.created_at, r.batch_id, r.position;
\ No newline at end of file
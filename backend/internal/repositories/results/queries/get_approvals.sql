@@ -0,0 +1,6 @@
+-- name: GetApprovals :many
+SELECT user_id, game_id, SUM(points)::BIGINT AS points
+FROM votes
+WHERE poll_id = sqlc.arg(poll_id) AND kind = 'approve'
+GROUP BY user_id, game_id
+ORDER BY user_id, game_id;
\ No newline at end of file
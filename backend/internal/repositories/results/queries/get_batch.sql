@@ -0,0 +1,4 @@
+-- name: GetBatch :many
+SELECT * FROM random_results
+WHERE room_id = $1 AND batch_id = $2
+ORDER BY position;
\ No newline at end of file
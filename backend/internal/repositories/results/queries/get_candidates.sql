@@ -0,0 +1,30 @@
+-- name: GetCandidates :many
+SELECT
+    g.id,
+    COALESCE(SUM(v.points) FILTER (WHERE v.kind = 'approve'), 0)::BIGINT AS votes,
+    COUNT(v.id) FILTER (WHERE v.kind = 'veto') AS vetoes,
+    (
+        g.id IN (
+            SELECT r.game_id
+            FROM random_results r
+            WHERE r.room_id = sqlc.arg(room_id) AND r.rerolled_at IS NULL AND r.revealed_at IS NOT NULL
+            ORDER BY r.created_at DESC
+            LIMIT sqlc.arg(cooldown_results)::INT
+        )
+        OR g.id IN (
+            SELECT r.game_id
+            FROM random_results r
+            WHERE r.room_id = sqlc.arg(room_id) AND r.rerolled_at IS NULL AND r.revealed_at IS NOT NULL
+              AND r.created_at > NOW() - make_interval(days => sqlc.arg(cooldown_days)::INT)
+        )
+    )::BOOLEAN AS cooled_down,
+    g.min_players,
+    g.max_players,
+    g.duration_minutes,
+    g.tags,
+    g.brought_by
+FROM games g
+LEFT JOIN votes v ON v.game_id = g.id AND v.poll_id = sqlc.arg(poll_id)
+WHERE g.room_id = sqlc.arg(room_id) AND g.archived_at IS NULL
+GROUP BY g.id
+ORDER BY g.id;
\ No newline at end of file
@@ -1,5 +1,5 @@
 -- name: GetLastResult :one
 SELECT * FROM random_results
-WHERE room_id = $1
+WHERE room_id = $1 AND rerolled_at IS NULL AND revealed_at IS NOT NULL
 ORDER BY created_at DESC
 LIMIT 1;
\ No newline at end of file
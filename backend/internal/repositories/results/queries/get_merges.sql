@@ -0,0 +1,4 @@
+-- name: GetMerges :many
+SELECT id, merged_into::UUID AS merged_into
+FROM games
+WHERE room_id = $1 AND merged_into IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- name: GetUnrevealed :many
+SELECT * FROM random_results
+WHERE revealed_at IS NULL
+ORDER BY lands_at, batch_id, position;
+
+-- name: HasUnrevealed :one
+-- церемония ещё идёт, пока есть нераскрытый результат, срок которого не наступил
+SELECT EXISTS (
+    SELECT 1 FROM random_results
+    WHERE room_id = $1 AND revealed_at IS NULL AND lands_at > NOW()
+);
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- name: GetWins :many
+WITH recent AS (
+    SELECT r.id, r.game_id, r.poll_id
+    FROM random_results r
+    WHERE r.room_id = sqlc.arg(room_id) AND r.rerolled_at IS NULL AND r.revealed_at IS NOT NULL
+    ORDER BY r.created_at DESC
+    LIMIT sqlc.arg(window_results)::INT
+)
+SELECT
+    voters.user_id,
+    COUNT(DISTINCT recent.id) AS wins
+FROM (
+    SELECT DISTINCT v.user_id
+    FROM votes v
+    WHERE v.poll_id = sqlc.arg(poll_id) AND v.kind = 'approve'
+) voters
+LEFT JOIN votes w ON w.user_id = voters.user_id AND w.kind = 'approve'
+LEFT JOIN recent ON recent.poll_id = w.poll_id AND recent.game_id = w.game_id
+GROUP BY voters.user_id
+ORDER BY voters.user_id;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- name: LockRoom :exec
+SELECT id FROM rooms WHERE id = $1 FOR UPDATE;
\ No newline at end of file
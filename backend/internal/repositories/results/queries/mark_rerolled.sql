@@ -0,0 +1,5 @@
+-- name: MarkRerolled :one
+UPDATE random_results
+SET rerolled_by = $2, rerolled_at = NOW(), reroll_reason = $3
+WHERE id = $1 AND rerolled_at IS NULL
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- name: Reveal :many
+UPDATE random_results
+SET revealed_at = NOW()
+WHERE room_id = $1 AND batch_id = $2 AND revealed_at IS NULL
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: UnmarkRerolled :exec
+UPDATE random_results
+SET rerolled_by = NULL, rerolled_at = NULL, reroll_reason = ''
+WHERE id = $1;
\ No newline at end of file
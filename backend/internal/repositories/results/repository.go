@@ -3,7 +3,9 @@ package results
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"time"
 
 	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/results/gen"
@@ -12,34 +14,78 @@ import (
 )
 
 type ResultRepository interface {
-	PickResult(context.Context, uuid.UUID) (string, error)
+	GetCandidates(context.Context, GetCandidatesParams) ([]entitiesrooms.Candidate, error)
 	GetLastResult(context.Context, uuid.UUID) (entitiesrooms.Result, error)
+	GetResult(context.Context, uuid.UUID, uuid.UUID) (entitiesrooms.Result, error)
 	GetAllResults(context.Context, uuid.UUID) ([]entitiesrooms.Result, error)
+	GetBatch(context.Context, uuid.UUID, uuid.UUID) ([]entitiesrooms.Result, error)
+	MarkRerolled(context.Context, MarkRerolledParams) (entitiesrooms.Result, int, error)
+	UnmarkRerolled(context.Context, uuid.UUID) error
+	GetWins(context.Context, GetWinsParams) ([]entitiesrooms.Fairness, error)
+	GetApprovals(context.Context, uuid.UUID) ([]entitiesrooms.Approval, error)
+	GetMerges(context.Context, uuid.UUID) (map[string]string, error)
+	Reveal(context.Context, uuid.UUID, uuid.UUID) ([]entitiesrooms.Result, error)
+	GetUnrevealed(context.Context) ([]entitiesrooms.Result, error)
+	HasUnrevealed(context.Context, uuid.UUID) (bool, error)
 	Delete(context.Context, uuid.UUID) error
 	Add(context.Context, AddParams) (entitiesrooms.Result, error)
+	AddBatch(context.Context, []AddParams) ([]entitiesrooms.Result, error)
+	DeleteBatch(context.Context, uuid.UUID, uuid.UUID) error
 }
 
 type Repository struct {
-	db *gen.Queries
+	conn *sql.DB
+	db   *gen.Queries
 }
 
 func NewRepository(db *sql.DB) *Repository {
-	return &Repository{db: gen.New(db)}
+	return &Repository{conn: db, db: gen.New(db)}
 }
 
-func (r *Repository) PickResult(ctx context.Context, roomID uuid.UUID) (string, error) {
-	gameID, err := r.db.PickResult(ctx, roomID)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		logger.Errorf(ctx, "PickResult error: %v; roomID: %v", err, roomID)
+// GetCandidatesParams - голоса считаются в раунде PollID; CooldownResults и CooldownDays задают,
+// сколько последних результатов и за сколько последних дней считаются недавними.
+type GetCandidatesParams struct {
+	RoomID          uuid.UUID
+	PollID          uuid.UUID
+	CooldownResults int32
+	CooldownDays    int32
+}
+
+func (r *Repository) GetCandidates(ctx context.Context, params GetCandidatesParams) ([]entitiesrooms.Candidate, error) {
+	items, err := r.db.GetCandidates(ctx, gen.GetCandidatesParams{
+		RoomID:          params.RoomID,
+		PollID:          params.PollID,
+		CooldownResults: params.CooldownResults,
+		CooldownDays:    params.CooldownDays,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "GetCandidates error: %v; data: %v", err, params)
 
-		return "", err
+		return nil, err
 	}
 
-	if errors.Is(err, sql.ErrNoRows) {
-		return "", nil
+	res := make([]entitiesrooms.Candidate, 0, len(items))
+	for _, it := range items {
+		var broughtBy string
+		if it.BroughtBy.Valid {
+			broughtBy = it.BroughtBy.UUID.String()
+		}
+
+		res = append(res, entitiesrooms.Candidate{
+			GameID:          it.ID.String(),
+			Votes:           it.Votes,
+			Vetoes:          it.Vetoes,
+			CooledDown:      it.CooledDown,
+			Score:           float64(it.Votes),
+			MinPlayers:      int(it.MinPlayers),
+			MaxPlayers:      int(it.MaxPlayers),
+			DurationMinutes: int(it.DurationMinutes),
+			Tags:            it.Tags,
+			BroughtBy:       broughtBy,
+		})
 	}
 
-	return gameID.String(), nil
+	return res, nil
 }
 
 func (r *Repository) GetLastResult(ctx context.Context, roomID uuid.UUID) (entitiesrooms.Result, error) {
@@ -54,13 +100,22 @@ func (r *Repository) GetLastResult(ctx context.Context, roomID uuid.UUID) (entit
 		return entitiesrooms.Result{}, err
 	}
 
-	return entitiesrooms.Result{
-		ID:        res.ID.String(),
-		RoomID:    res.RoomID.String(),
-		GameID:    res.GameID.String(),
-		ChosenBy:  res.ChosenBy.String(),
-		CreatedAt: res.CreatedAt.Time,
-	}, nil
+	return toEntity(res), nil
+}
+
+func (r *Repository) GetResult(ctx context.Context, id, roomID uuid.UUID) (entitiesrooms.Result, error) {
+	res, err := r.db.GetResult(ctx, gen.GetResultParams{ID: id, RoomID: roomID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.Result{}, nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "GetResult error: %v; id: %v", err, id)
+
+		return entitiesrooms.Result{}, err
+	}
+
+	return toEntity(res), nil
 }
 
 func (r *Repository) GetAllResults(ctx context.Context, roomID uuid.UUID) ([]entitiesrooms.Result, error) {
@@ -73,18 +128,224 @@ func (r *Repository) GetAllResults(ctx context.Context, roomID uuid.UUID) ([]ent
 
 	res := make([]entitiesrooms.Result, 0, len(items))
 	for _, it := range items {
-		res = append(res, entitiesrooms.Result{
-			ID:        it.ID.String(),
-			RoomID:    it.RoomID.String(),
-			GameID:    it.GameID.String(),
-			ChosenBy:  it.ChosenBy.String(),
-			CreatedAt: it.CreatedAt.Time,
+		result := toEntity(it.RandomResult)
+		result.GameTitle = it.GameTitle
+		result.GameArchived = it.GameArchived
+		res = append(res, result)
+	}
+
+	return res, nil
+}
+
+// GetBatch возвращает результаты подборки по местам.
+func (r *Repository) GetBatch(ctx context.Context, roomID, batchID uuid.UUID) ([]entitiesrooms.Result, error) {
+	items, err := r.db.GetBatch(ctx, gen.GetBatchParams{RoomID: roomID, BatchID: batchID})
+	if err != nil {
+		logger.Errorf(ctx, "GetResultBatch error: %v; batchID: %v", err, batchID)
+
+		return nil, err
+	}
+
+	res := make([]entitiesrooms.Result, 0, len(items))
+	for _, it := range items {
+		res = append(res, toEntity(it))
+	}
+
+	return res, nil
+}
+
+// MarkRerolledParams - результат ID комнаты RoomID отменяется, только если за последние
+// WindowHours часов в комнате отменено меньше Limit результатов.
+type MarkRerolledParams struct {
+	ID          uuid.UUID
+	RoomID      uuid.UUID
+	RerolledBy  uuid.UUID
+	Reason      string
+	Limit       int
+	WindowHours int32
+}
+
+// MarkRerolled отменяет результат перевыбором и возвращает его вместе с числом перевыборов
+// комнаты за окно до этой отмены. Строка комнаты блокируется до конца транзакции, поэтому
+// параллельные перевыборы считаются по очереди. Если лимит исчерпан или результат уже
+// отменён, возвращает пустой результат.
+func (r *Repository) MarkRerolled(ctx context.Context, params MarkRerolledParams) (entitiesrooms.Result, int, error) {
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Errorf(ctx, "MarkResultRerolled BeginTx error: %v", err)
+
+		return entitiesrooms.Result{}, 0, err
+	}
+	defer tx.Rollback()
+
+	q := r.db.WithTx(tx)
+	if err := q.LockRoom(ctx, params.RoomID); err != nil {
+		logger.Errorf(ctx, "MarkResultRerolled LockRoom error: %v; data: %v", err, params)
+
+		return entitiesrooms.Result{}, 0, err
+	}
+
+	count, err := q.CountRerolls(ctx, gen.CountRerollsParams{RoomID: params.RoomID, Hours: params.WindowHours})
+	if err != nil {
+		logger.Errorf(ctx, "MarkResultRerolled CountRerolls error: %v; data: %v", err, params)
+
+		return entitiesrooms.Result{}, 0, err
+	}
+	if int(count) >= params.Limit {
+		return entitiesrooms.Result{}, int(count), nil
+	}
+
+	result, err := q.MarkRerolled(ctx, gen.MarkRerolledParams{
+		ID:           params.ID,
+		RerolledBy:   uuid.NullUUID{UUID: params.RerolledBy, Valid: true},
+		RerollReason: params.Reason,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entitiesrooms.Result{}, int(count), nil
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "MarkResultRerolled error: %v; data: %v", err, params)
+
+		return entitiesrooms.Result{}, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Errorf(ctx, "MarkResultRerolled Commit error: %v", err)
+
+		return entitiesrooms.Result{}, 0, err
+	}
+
+	return toEntity(result), int(count), nil
+}
+
+// UnmarkRerolled снимает отмену с результата, если выбор замены не удался.
+func (r *Repository) UnmarkRerolled(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.UnmarkRerolled(ctx, id); err != nil {
+		logger.Errorf(ctx, "UnmarkResultRerolled error: %v; id: %v", err, id)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetWinsParams - для участников, голосовавших в раунде PollID, считается, сколько из последних
+// WindowResults результатов комнаты выиграли игры, за которые они голосовали в своём раунде.
+type GetWinsParams struct {
+	RoomID        uuid.UUID
+	PollID        uuid.UUID
+	WindowResults int32
+}
+
+// GetWins возвращает число побед участников без множителей - их считает сервис.
+func (r *Repository) GetWins(ctx context.Context, params GetWinsParams) ([]entitiesrooms.Fairness, error) {
+	items, err := r.db.GetWins(ctx, gen.GetWinsParams{
+		RoomID:        params.RoomID,
+		WindowResults: params.WindowResults,
+		PollID:        params.PollID,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "GetWins error: %v; data: %v", err, params)
+
+		return nil, err
+	}
+
+	res := make([]entitiesrooms.Fairness, 0, len(items))
+	for _, it := range items {
+		res = append(res, entitiesrooms.Fairness{
+			UserID: it.UserID.String(),
+			Wins:   int(it.Wins),
 		})
 	}
 
 	return res, nil
 }
 
+// GetApprovals возвращает очки одобрений каждого участника за каждую игру раунда.
+func (r *Repository) GetApprovals(ctx context.Context, pollID uuid.UUID) ([]entitiesrooms.Approval, error) {
+	items, err := r.db.GetApprovals(ctx, pollID)
+	if err != nil {
+		logger.Errorf(ctx, "GetApprovals error: %v; pollID: %v", err, pollID)
+
+		return nil, err
+	}
+
+	res := make([]entitiesrooms.Approval, 0, len(items))
+	for _, it := range items {
+		res = append(res, entitiesrooms.Approval{
+			UserID: it.UserID.String(),
+			GameID: it.GameID.String(),
+			Points: it.Points,
+		})
+	}
+
+	return res, nil
+}
+
+// GetMerges возвращает слитые игры комнаты: ID слитой игры -> ID игры, в которую её слили.
+func (r *Repository) GetMerges(ctx context.Context, roomID uuid.UUID) (map[string]string, error) {
+	items, err := r.db.GetMerges(ctx, roomID)
+	if err != nil {
+		logger.Errorf(ctx, "GetMerges error: %v; roomID: %v", err, roomID)
+
+		return nil, err
+	}
+
+	res := make(map[string]string, len(items))
+	for _, it := range items {
+		res[it.ID.String()] = it.MergedInto.String()
+	}
+
+	return res, nil
+}
+
+// Reveal раскрывает результаты подборки batchID. Если подборка уже раскрыта, возвращает пустой список.
+func (r *Repository) Reveal(ctx context.Context, roomID, batchID uuid.UUID) ([]entitiesrooms.Result, error) {
+	items, err := r.db.Reveal(ctx, gen.RevealParams{RoomID: roomID, BatchID: batchID})
+	if err != nil {
+		logger.Errorf(ctx, "RevealResults error: %v; batchID: %v", err, batchID)
+
+		return nil, err
+	}
+
+	res := make([]entitiesrooms.Result, 0, len(items))
+	for _, it := range items {
+		res = append(res, toEntity(it))
+	}
+
+	return res, nil
+}
+
+// GetUnrevealed возвращает нераскрытые результаты всех комнат по подборкам и местам.
+func (r *Repository) GetUnrevealed(ctx context.Context) ([]entitiesrooms.Result, error) {
+	items, err := r.db.GetUnrevealed(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "GetUnrevealedResults error: %v", err)
+
+		return nil, err
+	}
+
+	res := make([]entitiesrooms.Result, 0, len(items))
+	for _, it := range items {
+		res = append(res, toEntity(it))
+	}
+
+	return res, nil
+}
+
+// HasUnrevealed сообщает, идёт ли в комнате церемония выбора.
+func (r *Repository) HasUnrevealed(ctx context.Context, roomID uuid.UUID) (bool, error) {
+	pending, err := r.db.HasUnrevealed(ctx, roomID)
+	if err != nil {
+		logger.Errorf(ctx, "HasUnrevealedResults error: %v; roomID: %v", err, roomID)
+
+		return false, err
+	}
+
+	return pending, nil
+}
+
 func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
 	err := r.db.Delete(ctx, id)
 	if err != nil {
@@ -97,18 +358,97 @@ func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 type AddParams struct {
-	ID       uuid.UUID
-	RoomID   uuid.UUID
-	GameID   uuid.UUID
-	ChosenBy uuid.UUID
+	ID         uuid.UUID
+	RoomID     uuid.UUID
+	GameID     uuid.UUID
+	ChosenBy   uuid.UUID
+	Strategy   string
+	Seed       string
+	Commitment string
+	Snapshot   entitiesrooms.DrawSnapshot
+	Options    entitiesrooms.PickOptions
+	BatchID    uuid.UUID
+	Position   int32
+	PollID     uuid.UUID
+	RerollOf   uuid.NullUUID
+	LandsAt    time.Time
 }
 
 func (r *Repository) Add(ctx context.Context, params AddParams) (entitiesrooms.Result, error) {
-	result, err := r.db.Add(ctx, gen.AddParams{
-		ID:       params.ID,
-		RoomID:   params.RoomID,
-		GameID:   params.GameID,
-		ChosenBy: params.ChosenBy,
+	return add(ctx, r.db, params)
+}
+
+// AddBatch сохраняет результаты подборки одной транзакцией: подборка сохраняется целиком или не сохраняется.
+func (r *Repository) AddBatch(ctx context.Context, batch []AddParams) ([]entitiesrooms.Result, error) {
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Errorf(ctx, "AddResultBatch BeginTx error: %v", err)
+
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q := r.db.WithTx(tx)
+	res := make([]entitiesrooms.Result, 0, len(batch))
+	for _, params := range batch {
+		result, err := add(ctx, q, params)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, result)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Errorf(ctx, "AddResultBatch Commit error: %v", err)
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DeleteBatch удаляет нераскрытую подборку batchID, выбор которой не удалось завершить.
+func (r *Repository) DeleteBatch(ctx context.Context, roomID, batchID uuid.UUID) error {
+	err := r.db.DeleteBatch(ctx, gen.DeleteBatchParams{RoomID: roomID, BatchID: batchID})
+	if err != nil {
+		logger.Errorf(ctx, "DeleteResultBatch error: %v; batchID: %v", err, batchID)
+
+		return err
+	}
+
+	return nil
+}
+
+func add(ctx context.Context, q *gen.Queries, params AddParams) (entitiesrooms.Result, error) {
+	snapshot, err := json.Marshal(params.Snapshot)
+	if err != nil {
+		logger.Errorf(ctx, "AddResult marshal snapshot error: %v", err)
+
+		return entitiesrooms.Result{}, err
+	}
+
+	options, err := json.Marshal(params.Options)
+	if err != nil {
+		logger.Errorf(ctx, "AddResult marshal options error: %v", err)
+
+		return entitiesrooms.Result{}, err
+	}
+
+	result, err := q.Add(ctx, gen.AddParams{
+		ID:         params.ID,
+		RoomID:     params.RoomID,
+		GameID:     params.GameID,
+		ChosenBy:   params.ChosenBy,
+		Strategy:   params.Strategy,
+		Seed:       params.Seed,
+		Commitment: params.Commitment,
+		Snapshot:   snapshot,
+		Options:    options,
+		BatchID:    params.BatchID,
+		Position:   params.Position,
+		PollID:     params.PollID,
+		RerollOf:   params.RerollOf,
+		LandsAt:    sql.NullTime{Time: params.LandsAt, Valid: !params.LandsAt.IsZero()},
 	})
 	if err != nil {
 		logger.Errorf(ctx, "AddResult error: %v; data: %v", err, params)
@@ -116,11 +456,50 @@ func (r *Repository) Add(ctx context.Context, params AddParams) (entitiesrooms.R
 		return entitiesrooms.Result{}, err
 	}
 
+	return toEntity(result), nil
+}
+
+func toEntity(result gen.RandomResult) entitiesrooms.Result {
+	var snapshot entitiesrooms.DrawSnapshot
+	// Снимок сохраняется только сервером, результаты до commit-reveal содержат пустой объект.
+	_ = json.Unmarshal(result.Snapshot, &snapshot)
+
+	var options entitiesrooms.PickOptions
+	// Результаты до сохранения ограничений содержат пустой объект: перевыбор идёт без ограничений.
+	_ = json.Unmarshal(result.Options, &options)
+
+	var rerollOf string
+	if result.RerollOf.Valid {
+		rerollOf = result.RerollOf.UUID.String()
+	}
+
+	var reroll *entitiesrooms.Reroll
+	if result.RerolledAt.Valid {
+		reroll = &entitiesrooms.Reroll{
+			Reason: result.RerollReason,
+			At:     result.RerolledAt.Time,
+		}
+		if result.RerolledBy.Valid {
+			reroll.By = result.RerolledBy.UUID.String()
+		}
+	}
+
 	return entitiesrooms.Result{
-		ID:        result.ID.String(),
-		RoomID:    result.RoomID.String(),
-		GameID:    result.GameID.String(),
-		ChosenBy:  result.ChosenBy.String(),
-		CreatedAt: result.CreatedAt.Time,
-	}, nil
+		ID:         result.ID.String(),
+		RoomID:     result.RoomID.String(),
+		GameID:     result.GameID.String(),
+		ChosenBy:   result.ChosenBy.String(),
+		Strategy:   result.Strategy,
+		Seed:       result.Seed,
+		Commitment: result.Commitment,
+		Snapshot:   snapshot,
+		Options:    options,
+		BatchID:    result.BatchID.String(),
+		Position:   int(result.Position),
+		PollID:     result.PollID.String(),
+		RerollOf:   rerollOf,
+		Reroll:     reroll,
+		LandsAt:    result.LandsAt.Time,
+		CreatedAt:  result.CreatedAt.Time,
+	}
 }
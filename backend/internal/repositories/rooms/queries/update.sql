@@ -1,6 +1,18 @@
 -- name: Update :one
 UPDATE rooms
 SET
-    name = COALESCE($2, name)
+    name = COALESCE($2, name),
+    pick_strategy = COALESCE($3, pick_strategy),
+    veto_limit = COALESCE($4, veto_limit),
+    vote_budget = COALESCE($5, vote_budget),
+    quadratic_voting = COALESCE($6, quadratic_voting),
+    cooldown_results = COALESCE($7, cooldown_results),
+    cooldown_days = COALESCE($8, cooldown_days),
+    auto_pick = COALESCE($9, auto_pick),
+    ready_quorum = COALESCE($10, ready_quorum),
+    runoff_seconds = COALESCE($11, runoff_seconds),
+    reroll_limit = COALESCE($12, reroll_limit),
+    fairness = COALESCE($13, fairness),
+    available_only = COALESCE($14, available_only)
 WHERE id = $1
 RETURNING *;
\ No newline at end of file
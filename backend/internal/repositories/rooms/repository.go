@@ -44,12 +44,7 @@ func (r *Repository) Create(ctx context.Context, params CreateParams) (entitiesr
 		return entitiesrooms.Room{}, err
 	}
 
-	return entitiesrooms.Room{
-		ID:        created.ID.String(),
-		Name:      created.Name,
-		OwnerID:   created.OwnerID.String(),
-		CreatedAt: created.CreatedAt.Time,
-	}, nil
+	return toEntity(created), nil
 }
 
 func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (entitiesrooms.Room, error) {
@@ -63,12 +58,7 @@ func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (entitiesrooms.R
 		return entitiesrooms.Room{}, err
 	}
 
-	return entitiesrooms.Room{
-		ID:        res.ID.String(),
-		Name:      res.Name,
-		OwnerID:   res.OwnerID.String(),
-		CreatedAt: res.CreatedAt.Time,
-	}, nil
+	return toEntity(res), nil
 }
 
 func (r *Repository) GetAllForUser(ctx context.Context, userID uuid.UUID) ([]entitiesrooms.Room, error) {
@@ -80,37 +70,51 @@ func (r *Repository) GetAllForUser(ctx context.Context, userID uuid.UUID) ([]ent
 
 	res := make([]entitiesrooms.Room, 0, len(items))
 	for _, it := range items {
-		res = append(res, entitiesrooms.Room{
-			ID:        it.ID.String(),
-			Name:      it.Name,
-			OwnerID:   it.OwnerID.String(),
-			CreatedAt: it.CreatedAt.Time,
-		})
+		res = append(res, toEntity(it))
 	}
 	return res, nil
 }
 
 type UpdateParams struct {
-	ID   uuid.UUID
-	Name string
+	ID              uuid.UUID
+	Name            string
+	PickStrategy    string
+	VetoLimit       int32
+	VoteBudget      int32
+	QuadraticVoting bool
+	CooldownResults int32
+	CooldownDays    int32
+	AutoPick        bool
+	ReadyQuorum     int32
+	RunoffSeconds   int32
+	RerollLimit     int32
+	Fairness        bool
+	AvailableOnly   bool
 }
 
 func (r *Repository) Update(ctx context.Context, params UpdateParams) (entitiesrooms.Room, error) {
 	updatedRoom, err := r.db.Update(ctx, gen.UpdateParams{
-		ID:   params.ID,
-		Name: params.Name,
+		ID:              params.ID,
+		Name:            params.Name,
+		PickStrategy:    params.PickStrategy,
+		VetoLimit:       params.VetoLimit,
+		VoteBudget:      params.VoteBudget,
+		QuadraticVoting: params.QuadraticVoting,
+		CooldownResults: params.CooldownResults,
+		CooldownDays:    params.CooldownDays,
+		AutoPick:        params.AutoPick,
+		ReadyQuorum:     params.ReadyQuorum,
+		RunoffSeconds:   params.RunoffSeconds,
+		RerollLimit:     params.RerollLimit,
+		Fairness:        params.Fairness,
+		AvailableOnly:   params.AvailableOnly,
 	})
 	if err != nil {
 		logger.Errorf(ctx, "UpdateRoom error: %v; data: %v", err, params)
 		return entitiesrooms.Room{}, err
 	}
 
-	return entitiesrooms.Room{
-		ID:        updatedRoom.ID.String(),
-		Name:      updatedRoom.Name,
-		OwnerID:   updatedRoom.OwnerID.String(),
-		CreatedAt: updatedRoom.CreatedAt.Time,
-	}, nil
+	return toEntity(updatedRoom), nil
 }
 
 func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
@@ -121,3 +125,24 @@ func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 	return nil
 }
+
+func toEntity(room gen.Room) entitiesrooms.Room {
+	return entitiesrooms.Room{
+		ID:              room.ID.String(),
+		Name:            room.Name,
+		OwnerID:         room.OwnerID.String(),
+		PickStrategy:    room.PickStrategy,
+		VetoLimit:       int(room.VetoLimit),
+		VoteBudget:      int(room.VoteBudget),
+		QuadraticVoting: room.QuadraticVoting,
+		CooldownResults: int(room.CooldownResults),
+		CooldownDays:    int(room.CooldownDays),
+		AutoPick:        room.AutoPick,
+		ReadyQuorum:     int(room.ReadyQuorum),
+		RunoffSeconds:   int(room.RunoffSeconds),
+		RerollLimit:     int(room.RerollLimit),
+		Fairness:        room.Fairness,
+		AvailableOnly:   room.AvailableOnly,
+		CreatedAt:       room.CreatedAt.Time,
+	}
+}
@@ -1,6 +1,6 @@
 -- name: Add :one
 INSERT INTO votes (
-    id, room_id, game_id, user_id
+    id, room_id, game_id, user_id, kind, points, poll_id
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: CountVetoes :one
+SELECT COUNT(*)
+FROM votes
+WHERE poll_id = $1 AND user_id = $2 AND kind = 'veto';
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: GetForPoll :many
+SELECT *
+FROM votes
+WHERE poll_id = $1;
\ No newline at end of file
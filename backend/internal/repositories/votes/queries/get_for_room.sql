@@ -1,4 +0,0 @@
--- name: GetForRoom :many
-SELECT *
-FROM votes
-WHERE room_id = $1;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- name: GetSpent :one
+SELECT
+    COALESCE(SUM(points), 0)::BIGINT AS points,
+    COALESCE(SUM(points * points), 0)::BIGINT AS quadratic_points
+FROM votes
+WHERE poll_id = $1 AND user_id = $2 AND kind = 'approve';
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- name: HasGame :one
+SELECT EXISTS (
+    SELECT 1 FROM games
+    WHERE id = $1 AND room_id = $2 AND archived_at IS NULL
+);
\ No newline at end of file
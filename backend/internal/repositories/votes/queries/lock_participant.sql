@@ -0,0 +1,4 @@
+-- name: LockParticipant :exec
+SELECT id FROM room_participants
+WHERE room_id = $1 AND user_id = $2
+FOR UPDATE;
\ No newline at end of file
@@ -7,49 +7,102 @@ import (
 
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/votes/gen"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
 	"github.com/google/uuid"
 )
 
 type VoteRepository interface {
 	Add(ctx context.Context, params AddParams) (rooms.Vote, error)
 	Get(ctx context.Context, id uuid.UUID) (rooms.Vote, error)
-	GetForRoom(ctx context.Context, roomID uuid.UUID) ([]rooms.Vote, error)
+	GetForPoll(ctx context.Context, pollID uuid.UUID) ([]rooms.Vote, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	GetSpent(ctx context.Context, pollID, userID uuid.UUID) (Spent, error)
 }
 
 type Repository struct {
-	db *gen.Queries
+	conn *sql.DB
+	db   *gen.Queries
 }
 
 func NewRepository(db *sql.DB) *Repository {
-	return &Repository{db: gen.New(db)}
+	return &Repository{conn: db, db: gen.New(db)}
 }
 
+// AddParams - Check вызывается внутри транзакции с голосами, уже отданными участником
+// в раунде PollID. Если Check возвращает ошибку, голос не сохраняется, а ошибка
+// возвращается из Add без изменений.
 type AddParams struct {
 	ID     uuid.UUID
 	RoomID uuid.UUID
 	GameID uuid.UUID
 	UserID uuid.UUID
+	Kind   string
+	Points int32
+	PollID uuid.UUID
+	Check  func(Spent) error
 }
 
+// Add сохраняет голос. Строка участника комнаты блокируется до конца транзакции, поэтому
+// параллельные голоса участника проверяются по очереди и не превышают лимиты раунда.
+// Если игры нет в комнате или она в архиве, возвращает пустой голос.
 func (r *Repository) Add(ctx context.Context, params AddParams) (rooms.Vote, error) {
-	createdVote, err := r.db.Add(ctx, gen.AddParams{
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Errorf(ctx, "AddVote BeginTx error: %v", err)
+
+		return rooms.Vote{}, err
+	}
+	defer tx.Rollback()
+
+	q := r.db.WithTx(tx)
+	if err := q.LockParticipant(ctx, gen.LockParticipantParams{RoomID: params.RoomID, UserID: params.UserID}); err != nil {
+		logger.Errorf(ctx, "AddVote LockParticipant error: %v; data: %v", err, params)
+
+		return rooms.Vote{}, err
+	}
+
+	ok, err := q.HasGame(ctx, gen.HasGameParams{ID: params.GameID, RoomID: params.RoomID})
+	if err != nil {
+		logger.Errorf(ctx, "AddVote HasGame error: %v; data: %v", err, params)
+
+		return rooms.Vote{}, err
+	}
+	if !ok {
+		return rooms.Vote{}, nil
+	}
+
+	if params.Check != nil {
+		spent, err := getSpent(ctx, q, params.PollID, params.UserID)
+		if err != nil {
+			return rooms.Vote{}, err
+		}
+		if err := params.Check(spent); err != nil {
+			return rooms.Vote{}, err
+		}
+	}
+
+	createdVote, err := q.Add(ctx, gen.AddParams{
 		ID:     params.ID,
 		RoomID: params.RoomID,
 		GameID: params.GameID,
 		UserID: params.UserID,
+		Kind:   params.Kind,
+		Points: params.Points,
+		PollID: params.PollID,
 	})
 	if err != nil {
+		logger.Errorf(ctx, "AddVote error: %v; data: %v", err, params)
+
 		return rooms.Vote{}, err
 	}
 
-	return rooms.Vote{
-		ID:        createdVote.ID.String(),
-		RoomID:    createdVote.RoomID.String(),
-		GameID:    createdVote.GameID.String(),
-		UserID:    createdVote.UserID.String(),
-		CreatedAt: createdVote.CreatedAt.Time,
-	}, nil
+	if err := tx.Commit(); err != nil {
+		logger.Errorf(ctx, "AddVote Commit error: %v", err)
+
+		return rooms.Vote{}, err
+	}
+
+	return toEntity(createdVote), nil
 }
 
 func (r *Repository) Get(ctx context.Context, id uuid.UUID) (rooms.Vote, error) {
@@ -62,17 +115,11 @@ func (r *Repository) Get(ctx context.Context, id uuid.UUID) (rooms.Vote, error)
 		return rooms.Vote{}, err
 	}
 
-	return rooms.Vote{
-		ID:        vote.ID.String(),
-		RoomID:    vote.RoomID.String(),
-		GameID:    vote.GameID.String(),
-		UserID:    vote.UserID.String(),
-		CreatedAt: vote.CreatedAt.Time,
-	}, nil
+	return toEntity(vote), nil
 }
 
-func (r *Repository) GetForRoom(ctx context.Context, roomID uuid.UUID) ([]rooms.Vote, error) {
-	votes, err := r.db.GetForRoom(ctx, roomID)
+func (r *Repository) GetForPoll(ctx context.Context, pollID uuid.UUID) ([]rooms.Vote, error) {
+	votes, err := r.db.GetForPoll(ctx, pollID)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -83,13 +130,7 @@ func (r *Repository) GetForRoom(ctx context.Context, roomID uuid.UUID) ([]rooms.
 
 	result := make([]rooms.Vote, len(votes))
 	for i, vote := range votes {
-		result[i] = rooms.Vote{
-			ID:        vote.ID.String(),
-			RoomID:    vote.RoomID.String(),
-			GameID:    vote.GameID.String(),
-			UserID:    vote.UserID.String(),
-			CreatedAt: vote.CreatedAt.Time,
-		}
+		result[i] = toEntity(vote)
 	}
 
 	return result, nil
@@ -98,3 +139,56 @@ func (r *Repository) GetForRoom(ctx context.Context, roomID uuid.UUID) ([]rooms.
 func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.Delete(ctx, id)
 }
+
+// Spent - голоса участника в раунде: Points - сумма очков одобрений,
+// QuadraticPoints - сумма квадратов очков, Vetoes - число вето.
+type Spent struct {
+	Points          int64
+	QuadraticPoints int64
+	Vetoes          int64
+}
+
+func (r *Repository) GetSpent(ctx context.Context, pollID, userID uuid.UUID) (Spent, error) {
+	return getSpent(ctx, r.db, pollID, userID)
+}
+
+func getSpent(ctx context.Context, q *gen.Queries, pollID, userID uuid.UUID) (Spent, error) {
+	spent, err := q.GetSpent(ctx, gen.GetSpentParams{
+		PollID: pollID,
+		UserID: userID,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "GetSpentVotes error: %v; pollID: %v, userID: %v", err, pollID, userID)
+
+		return Spent{}, err
+	}
+
+	vetoes, err := q.CountVetoes(ctx, gen.CountVetoesParams{
+		PollID: pollID,
+		UserID: userID,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "CountVetoes error: %v; pollID: %v, userID: %v", err, pollID, userID)
+
+		return Spent{}, err
+	}
+
+	return Spent{
+		Points:          spent.Points,
+		QuadraticPoints: spent.QuadraticPoints,
+		Vetoes:          vetoes,
+	}, nil
+}
+
+func toEntity(vote gen.Vote) rooms.Vote {
+	return rooms.Vote{
+		ID:        vote.ID.String(),
+		RoomID:    vote.RoomID.String(),
+		GameID:    vote.GameID.String(),
+		UserID:    vote.UserID.String(),
+		Kind:      vote.Kind,
+		Points:    int(vote.Points),
+		PollID:    vote.PollID.String(),
+		CreatedAt: vote.CreatedAt.Time,
+	}
+}
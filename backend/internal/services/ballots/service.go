@@ -0,0 +1,222 @@
+package ballots
+
+import (
+	"context"
+	"errors"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
+	repositoryballots "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/ballots"
+	servicegames "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/games"
+	servicepolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/polls"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidBallot = errors.New("ballot must rank distinct games of the room")
+	ErrVotingClosed  = errors.New("voting in the poll is closed")
+)
+
+// OddsPublisher пересчитывает и рассылает шансы игр комнаты после изменения бюллетеней.
+type OddsPublisher interface {
+	PublishOdds(context.Context, string)
+}
+
+type BallotService interface {
+	Submit(context.Context, entitiesrooms.Ballot) (entitiesrooms.Ballot, error)
+	Get(context.Context, string, string) (entitiesrooms.Ballot, error)
+	GetForPoll(context.Context, string) ([]entitiesrooms.Ballot, error)
+	Delete(context.Context, string, string) error
+}
+
+// Service принимает ранжированные бюллетени в текущем раунде комнаты, как голоса:
+// у участника один бюллетень на раунд, бюллетени прошлых раундов остаются в истории.
+type Service struct {
+	repo        repositoryballots.BallotRepository
+	gameService servicegames.GameService
+	pollService servicepolls.PollService
+	odds        OddsPublisher
+	hub         hub.Hub
+}
+
+func NewService(repo repositoryballots.BallotRepository, gameService servicegames.GameService, pollService servicepolls.PollService) *Service {
+	return &Service{repo: repo, gameService: gameService, pollService: pollService}
+}
+
+func (s *Service) SetHub(h hub.Hub) {
+	s.hub = h
+}
+
+func (s *Service) SetOdds(odds OddsPublisher) {
+	s.odds = odds
+}
+
+// Submit сохраняет бюллетень участника в текущем раунде, заменяя предыдущий бюллетень раунда.
+func (s *Service) Submit(ctx context.Context, ballot entitiesrooms.Ballot) (entitiesrooms.Ballot, error) {
+	id, err := uuid.Parse(ballot.ID)
+	if err != nil {
+		logger.Errorf(ctx, "SubmitBallot invalid ID: %v", err)
+
+		return entitiesrooms.Ballot{}, err
+	}
+
+	roomID, err := uuid.Parse(ballot.RoomID)
+	if err != nil {
+		logger.Errorf(ctx, "SubmitBallot invalid RoomID: %v", err)
+
+		return entitiesrooms.Ballot{}, err
+	}
+
+	userID, err := uuid.Parse(ballot.UserID)
+	if err != nil {
+		logger.Errorf(ctx, "SubmitBallot invalid UserID: %v", err)
+
+		return entitiesrooms.Ballot{}, err
+	}
+
+	pollID, err := s.openPoll(ctx, ballot.RoomID)
+	if err != nil {
+		return entitiesrooms.Ballot{}, err
+	}
+
+	games, err := s.gameService.GetAllRoomGames(ctx, ballot.RoomID)
+	if err != nil {
+		return entitiesrooms.Ballot{}, err
+	}
+
+	roomGames := make(map[string]struct{}, len(games))
+	for _, game := range games {
+		roomGames[game.ID] = struct{}{}
+	}
+
+	if len(ballot.Rankings) == 0 {
+		return entitiesrooms.Ballot{}, ErrInvalidBallot
+	}
+
+	rankings := make([]uuid.UUID, 0, len(ballot.Rankings))
+	seen := make(map[uuid.UUID]struct{}, len(ballot.Rankings))
+	for _, gameID := range ballot.Rankings {
+		uuidGameID, err := uuid.Parse(gameID)
+		if err != nil {
+			return entitiesrooms.Ballot{}, ErrInvalidBallot
+		}
+		if _, ok := roomGames[uuidGameID.String()]; !ok {
+			return entitiesrooms.Ballot{}, ErrInvalidBallot
+		}
+		if _, ok := seen[uuidGameID]; ok {
+			return entitiesrooms.Ballot{}, ErrInvalidBallot
+		}
+		seen[uuidGameID] = struct{}{}
+		rankings = append(rankings, uuidGameID)
+	}
+
+	result, err := s.repo.Upsert(ctx, repositoryballots.UpsertParams{
+		ID:       id,
+		RoomID:   roomID,
+		UserID:   userID,
+		Rankings: rankings,
+		PollID:   pollID,
+	})
+	if err == nil && s.hub != nil {
+		s.hub.Broadcast(ballot.RoomID, hub.RoomEvent{
+			Type:   hub.EventBallotSubmitted,
+			RoomID: ballot.RoomID,
+			Payload: map[string]any{
+				"id":       result.ID,
+				"user_id":  result.UserID,
+				"rankings": result.Rankings,
+			},
+		})
+	}
+	if err == nil && s.odds != nil {
+		s.odds.PublishOdds(ctx, ballot.RoomID)
+	}
+	return result, err
+}
+
+// Get возвращает бюллетень участника в текущем раунде комнаты.
+func (s *Service) Get(ctx context.Context, roomID, userID string) (entitiesrooms.Ballot, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Errorf(ctx, "GetBallot invalid UserID: %v", err)
+
+		return entitiesrooms.Ballot{}, err
+	}
+
+	poll, err := s.pollService.Current(ctx, roomID)
+	if err != nil {
+		return entitiesrooms.Ballot{}, err
+	}
+
+	uuidPollID, err := uuid.Parse(poll.ID)
+	if err != nil {
+		logger.Errorf(ctx, "GetBallot invalid PollID: %v", err)
+
+		return entitiesrooms.Ballot{}, err
+	}
+
+	return s.repo.Get(ctx, uuidPollID, uuidUserID)
+}
+
+func (s *Service) GetForPoll(ctx context.Context, pollID string) ([]entitiesrooms.Ballot, error) {
+	uuidPollID, err := uuid.Parse(pollID)
+	if err != nil {
+		logger.Errorf(ctx, "GetBallotsForPoll invalid PollID: %v", err)
+
+		return nil, err
+	}
+
+	return s.repo.GetForPoll(ctx, uuidPollID)
+}
+
+// Delete удаляет бюллетень участника в текущем раунде, пока раунд открыт.
+func (s *Service) Delete(ctx context.Context, roomID, userID string) error {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Errorf(ctx, "DeleteBallot invalid UserID: %v", err)
+
+		return err
+	}
+
+	pollID, err := s.openPoll(ctx, roomID)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Delete(ctx, pollID, uuidUserID)
+	if err == nil && s.hub != nil {
+		s.hub.Broadcast(roomID, hub.RoomEvent{
+			Type:   hub.EventBallotDeleted,
+			RoomID: roomID,
+			Payload: map[string]any{
+				"user_id": userID,
+			},
+		})
+	}
+	if err == nil && s.odds != nil {
+		s.odds.PublishOdds(ctx, roomID)
+	}
+	return err
+}
+
+// openPoll возвращает текущий раунд комнаты, если он открыт. Бюллетени закрытых раундов
+// остаются в истории без изменений.
+func (s *Service) openPoll(ctx context.Context, roomID string) (uuid.UUID, error) {
+	poll, err := s.pollService.Current(ctx, roomID)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if !poll.IsOpen() {
+		return uuid.UUID{}, ErrVotingClosed
+	}
+
+	pollID, err := uuid.Parse(poll.ID)
+	if err != nil {
+		logger.Errorf(ctx, "Ballot invalid PollID: %v", err)
+
+		return uuid.UUID{}, err
+	}
+
+	return pollID, nil
+}
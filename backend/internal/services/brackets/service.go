@@ -0,0 +1,588 @@
+package brackets
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
+	repositorybrackets "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/brackets"
+	servicegames "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/games"
+	serviceparticipants "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/participants"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBracketActive    = errors.New("room already has an active bracket")
+	ErrNotEnoughGames   = errors.New("bracket needs at least two games")
+	ErrNoActiveBracket  = errors.New("room has no active bracket")
+	ErrMatchNotFound    = errors.New("match not found")
+	ErrMatchNotOpen     = errors.New("match is not open for voting")
+	ErrInvalidMatchVote = errors.New("game does not play in this match")
+)
+
+type BracketService interface {
+	Start(context.Context, string, string, int) (entitiesrooms.Bracket, error)
+	Get(context.Context, string) (entitiesrooms.Bracket, error)
+	Vote(context.Context, string, string, string, string) (entitiesrooms.BracketMatch, error)
+	Cancel(context.Context, string) error
+	Restore(context.Context) error
+}
+
+// Service проводит турнир на выбывание: матчи идут по одному, матч закрывается,
+// когда проголосовали все участники комнаты или истёк таймер, победители выходят
+// в следующий раунд, пока не останется одна игра.
+// Все изменения состояния турниров выполняются под mu. Таймеры матчей живут в памяти,
+// поэтому после перезапуска они восстанавливаются из базы вызовом Restore.
+type Service struct {
+	repo               repositorybrackets.BracketRepository
+	gameService        servicegames.GameService
+	participantService serviceparticipants.ParticipantService
+	hub                hub.Hub
+	mu                 sync.Mutex
+}
+
+func NewService(repo repositorybrackets.BracketRepository, gameService servicegames.GameService, participantService serviceparticipants.ParticipantService) *Service {
+	return &Service{repo: repo, gameService: gameService, participantService: participantService}
+}
+
+func (s *Service) SetHub(h hub.Hub) {
+	s.hub = h
+}
+
+// Start создаёт турнир из игр комнаты в случайном порядке и открывает первый матч.
+func (s *Service) Start(ctx context.Context, roomID, userID string, matchSeconds int) (entitiesrooms.Bracket, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "StartBracket invalid RoomID: %v", err)
+
+		return entitiesrooms.Bracket{}, err
+	}
+
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Errorf(ctx, "StartBracket invalid UserID: %v", err)
+
+		return entitiesrooms.Bracket{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	active, err := s.repo.GetActive(ctx, uuidRoomID)
+	if err != nil {
+		return entitiesrooms.Bracket{}, err
+	}
+	if active.ID != "" {
+		return entitiesrooms.Bracket{}, ErrBracketActive
+	}
+
+	games, err := s.gameService.GetAllRoomGames(ctx, roomID)
+	if err != nil {
+		return entitiesrooms.Bracket{}, err
+	}
+	if len(games) < 2 {
+		return entitiesrooms.Bracket{}, ErrNotEnoughGames
+	}
+
+	gameIDs := make([]string, 0, len(games))
+	for _, game := range games {
+		gameIDs = append(gameIDs, game.ID)
+	}
+	rand.Shuffle(len(gameIDs), func(i, j int) {
+		gameIDs[i], gameIDs[j] = gameIDs[j], gameIDs[i]
+	})
+
+	bracket, err := s.repo.Create(ctx, repositorybrackets.CreateParams{
+		ID:           uuid.New(),
+		RoomID:       uuidRoomID,
+		MatchSeconds: int32(matchSeconds),
+		CreatedBy:    uuidUserID,
+	})
+	if err != nil {
+		return entitiesrooms.Bracket{}, err
+	}
+
+	if err := s.addRound(ctx, bracket, 1, gameIDs); err != nil {
+		return entitiesrooms.Bracket{}, err
+	}
+
+	bracket, err = s.load(ctx, bracket)
+	if err != nil {
+		return entitiesrooms.Bracket{}, err
+	}
+
+	if s.hub != nil {
+		s.hub.Broadcast(roomID, hub.RoomEvent{
+			Type:   hub.EventBracketStarted,
+			RoomID: roomID,
+			Payload: map[string]any{
+				"id":            bracket.ID,
+				"match_seconds": bracket.MatchSeconds,
+				"matches":       bracket.Matches,
+			},
+		})
+	}
+
+	if err := s.advance(ctx, bracket); err != nil {
+		return entitiesrooms.Bracket{}, err
+	}
+	return s.load(ctx, bracket)
+}
+
+// Get возвращает последний турнир комнаты с матчами и голосами.
+// Матч, таймер которого истёк, пока сервер был недоступен, закрывается здесь.
+func (s *Service) Get(ctx context.Context, roomID string) (entitiesrooms.Bracket, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "GetBracket invalid RoomID: %v", err)
+
+		return entitiesrooms.Bracket{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bracket, err := s.repo.GetLatest(ctx, uuidRoomID)
+	if err != nil || bracket.ID == "" {
+		return bracket, err
+	}
+
+	bracket, err = s.load(ctx, bracket)
+	if err != nil {
+		return entitiesrooms.Bracket{}, err
+	}
+
+	if bracket.Status != entitiesrooms.BracketStatusActive {
+		return bracket, nil
+	}
+
+	for _, match := range bracket.Matches {
+		if match.Status == entitiesrooms.MatchStatusOpen && time.Now().After(match.ClosesAt) {
+			if err := s.closeMatch(ctx, bracket, match); err != nil {
+				return entitiesrooms.Bracket{}, err
+			}
+
+			bracket, err = s.repo.GetLatest(ctx, uuidRoomID)
+			if err != nil {
+				return entitiesrooms.Bracket{}, err
+			}
+			return s.load(ctx, bracket)
+		}
+	}
+	return bracket, nil
+}
+
+// Vote сохраняет голос участника в открытом матче. Повторный голос заменяет предыдущий.
+// Когда проголосовали все участники комнаты, матч закрывается досрочно.
+func (s *Service) Vote(ctx context.Context, roomID, matchID, userID, gameID string) (entitiesrooms.BracketMatch, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "BracketVote invalid RoomID: %v", err)
+
+		return entitiesrooms.BracketMatch{}, err
+	}
+
+	uuidMatchID, err := uuid.Parse(matchID)
+	if err != nil {
+		return entitiesrooms.BracketMatch{}, ErrMatchNotFound
+	}
+
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Errorf(ctx, "BracketVote invalid UserID: %v", err)
+
+		return entitiesrooms.BracketMatch{}, err
+	}
+
+	uuidGameID, err := uuid.Parse(gameID)
+	if err != nil {
+		return entitiesrooms.BracketMatch{}, ErrInvalidMatchVote
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	match, err := s.repo.GetMatch(ctx, uuidMatchID, uuidRoomID)
+	if err != nil {
+		return entitiesrooms.BracketMatch{}, err
+	}
+	if match.ID == "" {
+		return entitiesrooms.BracketMatch{}, ErrMatchNotFound
+	}
+
+	bracket, err := s.repo.GetActive(ctx, uuidRoomID)
+	if err != nil {
+		return entitiesrooms.BracketMatch{}, err
+	}
+	if bracket.ID != match.BracketID || match.Status != entitiesrooms.MatchStatusOpen {
+		return entitiesrooms.BracketMatch{}, ErrMatchNotOpen
+	}
+	// Таймер матча мог не сработать, пока сервер был недоступен: матч закрывается здесь.
+	if time.Now().After(match.ClosesAt) {
+		if err := s.closeMatch(ctx, bracket, match); err != nil {
+			return entitiesrooms.BracketMatch{}, err
+		}
+		return entitiesrooms.BracketMatch{}, ErrMatchNotOpen
+	}
+	if !match.HasGame(uuidGameID.String()) {
+		return entitiesrooms.BracketMatch{}, ErrInvalidMatchVote
+	}
+
+	err = s.repo.UpsertVote(ctx, repositorybrackets.UpsertVoteParams{
+		MatchID: uuidMatchID,
+		UserID:  uuidUserID,
+		GameID:  uuidGameID,
+	})
+	if err != nil {
+		return entitiesrooms.BracketMatch{}, err
+	}
+
+	bracketID, err := uuid.Parse(bracket.ID)
+	if err != nil {
+		return entitiesrooms.BracketMatch{}, err
+	}
+
+	counts, err := s.repo.GetVoteCounts(ctx, bracketID)
+	if err != nil {
+		return entitiesrooms.BracketMatch{}, err
+	}
+	match.Votes = votesFor(counts, match.ID)
+
+	_, roles, err := s.participantService.GetAllParticipants(ctx, roomID)
+	if err != nil {
+		return entitiesrooms.BracketMatch{}, err
+	}
+
+	voted := 0
+	for _, votes := range match.Votes {
+		voted += votes
+	}
+	if voted < len(roles) {
+		return match, nil
+	}
+
+	if err := s.closeMatch(ctx, bracket, match); err != nil {
+		return entitiesrooms.BracketMatch{}, err
+	}
+
+	match, err = s.repo.GetMatch(ctx, uuidMatchID, uuidRoomID)
+	if err != nil {
+		return entitiesrooms.BracketMatch{}, err
+	}
+	match.Votes = votesFor(counts, match.ID)
+	return match, nil
+}
+
+// Cancel останавливает активный турнир комнаты без победителя.
+func (s *Service) Cancel(ctx context.Context, roomID string) error {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "CancelBracket invalid RoomID: %v", err)
+
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bracket, err := s.repo.GetActive(ctx, uuidRoomID)
+	if err != nil {
+		return err
+	}
+	if bracket.ID == "" {
+		return ErrNoActiveBracket
+	}
+
+	return s.finish(ctx, bracket, entitiesrooms.BracketStatusCancelled, "")
+}
+
+// Restore ставит таймеры открытых матчей всех активных турниров. Матчи, истёкшие
+// за время простоя сервера, закрываются сразу.
+func (s *Service) Restore(ctx context.Context) error {
+	pending, err := s.repo.GetPending(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, bracket := range pending {
+		for _, match := range bracket.Matches {
+			s.schedule(bracket, match)
+		}
+	}
+	logger.Infof(ctx, "Restored %d bracket match timers", len(pending))
+	return nil
+}
+
+// addRound создаёт матчи раунда из игр по парам. Игра без пары сразу проходит дальше.
+func (s *Service) addRound(ctx context.Context, bracket entitiesrooms.Bracket, round int, gameIDs []string) error {
+	bracketID, err := uuid.Parse(bracket.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(gameIDs); i += 2 {
+		gameA, err := uuid.Parse(gameIDs[i])
+		if err != nil {
+			return err
+		}
+
+		params := repositorybrackets.AddMatchParams{
+			ID:        uuid.New(),
+			BracketID: bracketID,
+			Round:     int32(round),
+			Position:  int32(i / 2),
+			GameA:     gameA,
+			Status:    entitiesrooms.MatchStatusPending,
+		}
+		if i+1 < len(gameIDs) {
+			gameB, err := uuid.Parse(gameIDs[i+1])
+			if err != nil {
+				return err
+			}
+			params.GameB = uuid.NullUUID{UUID: gameB, Valid: true}
+		} else {
+			params.Status = entitiesrooms.MatchStatusClosed
+			params.WinnerGameID = uuid.NullUUID{UUID: gameA, Valid: true}
+		}
+
+		if _, err := s.repo.AddMatch(ctx, params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// advance открывает следующий матч турнира. Если раунд сыгран, создаёт следующий
+// раунд из победителей, а если победитель один - завершает турнир.
+func (s *Service) advance(ctx context.Context, bracket entitiesrooms.Bracket) error {
+	bracketID, err := uuid.Parse(bracket.ID)
+	if err != nil {
+		return err
+	}
+
+	for {
+		matches, err := s.repo.GetMatches(ctx, bracketID)
+		if err != nil {
+			return err
+		}
+		if len(matches) == 0 {
+			return nil
+		}
+
+		for _, match := range matches {
+			if match.Status == entitiesrooms.MatchStatusOpen {
+				return nil
+			}
+		}
+
+		for _, match := range matches {
+			if match.Status == entitiesrooms.MatchStatusPending {
+				return s.openMatch(ctx, bracket, match)
+			}
+		}
+
+		lastRound := matches[len(matches)-1].Round
+		var winners []string
+		for _, match := range matches {
+			if match.Round == lastRound {
+				winners = append(winners, match.WinnerGameID)
+			}
+		}
+
+		if len(winners) == 1 {
+			return s.finish(ctx, bracket, entitiesrooms.BracketStatusFinished, winners[0])
+		}
+
+		if err := s.addRound(ctx, bracket, lastRound+1, winners); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Service) openMatch(ctx context.Context, bracket entitiesrooms.Bracket, match entitiesrooms.BracketMatch) error {
+	matchID, err := uuid.Parse(match.ID)
+	if err != nil {
+		return err
+	}
+
+	openedAt := time.Now()
+	closesAt := openedAt.Add(time.Duration(bracket.MatchSeconds) * time.Second)
+	opened, err := s.repo.OpenMatch(ctx, matchID, openedAt, closesAt)
+	if err != nil || opened.ID == "" {
+		return err
+	}
+
+	if s.hub != nil {
+		s.hub.Broadcast(bracket.RoomID, hub.RoomEvent{
+			Type:   hub.EventBracketMatchOpened,
+			RoomID: bracket.RoomID,
+			Payload: map[string]any{
+				"id":         opened.ID,
+				"bracket_id": opened.BracketID,
+				"round":      opened.Round,
+				"position":   opened.Position,
+				"game_a":     opened.GameA,
+				"game_b":     opened.GameB,
+				"closes_at":  opened.ClosesAt,
+			},
+		})
+	}
+
+	s.schedule(bracket, opened)
+	return nil
+}
+
+// schedule ставит таймер, который закроет открытый матч в ClosesAt.
+func (s *Service) schedule(bracket entitiesrooms.Bracket, match entitiesrooms.BracketMatch) {
+	time.AfterFunc(time.Until(match.ClosesAt), func() {
+		s.closeExpired(bracket, match)
+	})
+}
+
+// closeExpired закрывает матч по таймеру, если турнир всё ещё идёт.
+func (s *Service) closeExpired(bracket entitiesrooms.Bracket, match entitiesrooms.BracketMatch) {
+	ctx := context.Background()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	roomID, err := uuid.Parse(bracket.RoomID)
+	if err != nil {
+		return
+	}
+
+	active, err := s.repo.GetActive(ctx, roomID)
+	if err != nil || active.ID != bracket.ID {
+		return
+	}
+
+	if err := s.closeMatch(ctx, active, match); err != nil {
+		logger.Errorf(ctx, "CloseBracketMatch by timer error: %v; matchID: %v", err, match.ID)
+	}
+}
+
+// closeMatch подводит итог матча: побеждает игра с большим числом голосов,
+// при равенстве победитель выбирается случайно.
+func (s *Service) closeMatch(ctx context.Context, bracket entitiesrooms.Bracket, match entitiesrooms.BracketMatch) error {
+	bracketID, err := uuid.Parse(bracket.ID)
+	if err != nil {
+		return err
+	}
+
+	matchID, err := uuid.Parse(match.ID)
+	if err != nil {
+		return err
+	}
+
+	counts, err := s.repo.GetVoteCounts(ctx, bracketID)
+	if err != nil {
+		return err
+	}
+	votes := votesFor(counts, match.ID)
+
+	winner := match.GameA
+	switch {
+	case votes[match.GameB] > votes[match.GameA]:
+		winner = match.GameB
+	case votes[match.GameB] == votes[match.GameA] && rand.IntN(2) == 1:
+		winner = match.GameB
+	}
+
+	winnerID, err := uuid.Parse(winner)
+	if err != nil {
+		return err
+	}
+
+	closed, err := s.repo.CloseMatch(ctx, matchID, winnerID)
+	if err != nil || closed.ID == "" {
+		return err
+	}
+
+	if s.hub != nil {
+		s.hub.Broadcast(bracket.RoomID, hub.RoomEvent{
+			Type:   hub.EventBracketMatchClosed,
+			RoomID: bracket.RoomID,
+			Payload: map[string]any{
+				"id":             closed.ID,
+				"bracket_id":     closed.BracketID,
+				"round":          closed.Round,
+				"position":       closed.Position,
+				"winner_game_id": closed.WinnerGameID,
+				"votes":          votes,
+			},
+		})
+	}
+
+	return s.advance(ctx, bracket)
+}
+
+func (s *Service) finish(ctx context.Context, bracket entitiesrooms.Bracket, status string, winnerGameID string) error {
+	bracketID, err := uuid.Parse(bracket.ID)
+	if err != nil {
+		return err
+	}
+
+	params := repositorybrackets.FinishParams{
+		ID:     bracketID,
+		Status: status,
+	}
+	if winnerGameID != "" {
+		winnerID, err := uuid.Parse(winnerGameID)
+		if err != nil {
+			return err
+		}
+		params.WinnerGameID = uuid.NullUUID{UUID: winnerID, Valid: true}
+	}
+
+	finished, err := s.repo.Finish(ctx, params)
+	if err == nil && finished.ID != "" && s.hub != nil {
+		s.hub.Broadcast(bracket.RoomID, hub.RoomEvent{
+			Type:   hub.EventBracketFinished,
+			RoomID: bracket.RoomID,
+			Payload: map[string]any{
+				"id":             finished.ID,
+				"status":         finished.Status,
+				"winner_game_id": finished.WinnerGameID,
+			},
+		})
+	}
+	return err
+}
+
+// load дополняет турнир матчами и голосами в них.
+func (s *Service) load(ctx context.Context, bracket entitiesrooms.Bracket) (entitiesrooms.Bracket, error) {
+	bracketID, err := uuid.Parse(bracket.ID)
+	if err != nil {
+		return entitiesrooms.Bracket{}, err
+	}
+
+	matches, err := s.repo.GetMatches(ctx, bracketID)
+	if err != nil {
+		return entitiesrooms.Bracket{}, err
+	}
+
+	counts, err := s.repo.GetVoteCounts(ctx, bracketID)
+	if err != nil {
+		return entitiesrooms.Bracket{}, err
+	}
+
+	for i := range matches {
+		matches[i].Votes = votesFor(counts, matches[i].ID)
+	}
+	bracket.Matches = matches
+	return bracket, nil
+}
+
+// votesFor возвращает голоса матча; для матча без голосов - пустую карту.
+func votesFor(counts map[string]map[string]int, matchID string) map[string]int {
+	votes := make(map[string]int)
+	for gameID, count := range counts[matchID] {
+		votes[gameID] = count
+	}
+	return votes
+}
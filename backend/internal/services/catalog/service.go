@@ -0,0 +1,113 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	entitiescatalog "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/catalog"
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	repositorycatalog "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/catalog"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/google/uuid"
+)
+
+var ErrCatalogGameNotFound = errors.New("catalog game not found")
+
+// Ограничения выдачи поиска по каталогу.
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 50
+)
+
+type CatalogService interface {
+	Search(context.Context, string, int) ([]entitiescatalog.Game, error)
+	Get(context.Context, string) (entitiescatalog.Game, error)
+	Stats(context.Context, string) (entitiescatalog.Stats, error)
+	Ensure(context.Context, entitiesrooms.Game, string) (entitiescatalog.Game, error)
+}
+
+type Service struct {
+	repo repositorycatalog.CatalogRepository
+}
+
+func NewService(repo repositorycatalog.CatalogRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// Search ищет игры каталога по началу названия и по похожести (триграммы).
+// Пустой запрос возвращает каталог по алфавиту, limit вне [1, MaxSearchLimit]
+// заменяется на DefaultSearchLimit.
+func (s *Service) Search(ctx context.Context, query string, limit int) ([]entitiescatalog.Game, error) {
+	if limit <= 0 || limit > MaxSearchLimit {
+		limit = DefaultSearchLimit
+	}
+
+	query = strings.TrimSpace(query)
+	return s.repo.Search(ctx, repositorycatalog.SearchParams{
+		Query:      query,
+		Prefix:     escapeLike(strings.ToLower(query)),
+		MaxResults: int32(limit),
+	})
+}
+
+// Get возвращает игру каталога или ErrCatalogGameNotFound.
+func (s *Service) Get(ctx context.Context, id string) (entitiescatalog.Game, error) {
+	uuidID, err := uuid.Parse(id)
+	if err != nil {
+		logger.Errorf(ctx, "GetCatalogGame invalid ID: %v", err)
+
+		return entitiescatalog.Game{}, ErrCatalogGameNotFound
+	}
+
+	game, err := s.repo.Get(ctx, uuidID)
+	if err != nil {
+		return entitiescatalog.Game{}, err
+	}
+	if game.ID == "" {
+		return entitiescatalog.Game{}, ErrCatalogGameNotFound
+	}
+	return game, nil
+}
+
+func (s *Service) Stats(ctx context.Context, id string) (entitiescatalog.Stats, error) {
+	uuidID, err := uuid.Parse(id)
+	if err != nil {
+		logger.Errorf(ctx, "GetCatalogStats invalid ID: %v", err)
+
+		return entitiescatalog.Stats{}, err
+	}
+
+	return s.repo.GetStats(ctx, uuidID)
+}
+
+// Ensure возвращает игру каталога с названием игры комнаты без учёта регистра,
+// а если такой нет - заносит в каталог описание игры комнаты от имени userID.
+func (s *Service) Ensure(ctx context.Context, game entitiesrooms.Game, userID string) (entitiescatalog.Game, error) {
+	var createdBy uuid.NullUUID
+	if userID != "" {
+		uuidUserID, err := uuid.Parse(userID)
+		if err != nil {
+			logger.Errorf(ctx, "EnsureCatalogGame invalid UserID: %v", err)
+
+			return entitiescatalog.Game{}, err
+		}
+		createdBy = uuid.NullUUID{UUID: uuidUserID, Valid: true}
+	}
+
+	return s.repo.Ensure(ctx, repositorycatalog.EnsureParams{
+		ID:              uuid.New(),
+		Title:           game.Title,
+		MinPlayers:      int32(game.MinPlayers),
+		MaxPlayers:      int32(game.MaxPlayers),
+		DurationMinutes: int32(game.DurationMinutes),
+		Tags:            game.Tags,
+		Link:            game.Link,
+		CreatedBy:       createdBy,
+	})
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,180 @@
+package deadlines
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
+	servicepolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/polls"
+	serviceresults "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/results"
+	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+)
+
+const (
+	// pickRetries - сколько раз повторяется выбор по сроку, если в комнате идёт другой выбор.
+	pickRetries = 3
+	// pickRetryDelay - пауза перед повтором, дольше церемонии выбора.
+	pickRetryDelay = 10 * time.Second
+)
+
+type DeadlineService interface {
+	Set(context.Context, string, time.Time) (entitiesrooms.Poll, error)
+	Restore(context.Context) error
+}
+
+// Service - планировщик сроков голосования. Когда срок раунда наступает, сервер
+// закрывает голосование и выбирает игру от имени владельца комнаты, даже если
+// в комнате никого нет. Сроки хранятся в раундах, поэтому после перезапуска
+// таймеры восстанавливаются из базы вызовом Restore.
+type Service struct {
+	pollService   servicepolls.PollService
+	resultService serviceresults.ResultService
+	roomService   servicerooms.RoomService
+	hub           hub.Hub
+	timers        map[string]*time.Timer
+	mu            sync.Mutex
+}
+
+func NewService(pollService servicepolls.PollService, resultService serviceresults.ResultService, roomService servicerooms.RoomService) *Service {
+	return &Service{
+		pollService:   pollService,
+		resultService: resultService,
+		roomService:   roomService,
+		timers:        make(map[string]*time.Timer),
+	}
+}
+
+func (s *Service) SetHub(h hub.Hub) {
+	s.hub = h
+}
+
+// Set задаёт срок голосования текущего раунда комнаты и переставляет его таймер.
+// Нулевое время снимает срок.
+func (s *Service) Set(ctx context.Context, roomID string, deadline time.Time) (entitiesrooms.Poll, error) {
+	poll, err := s.pollService.SetDeadline(ctx, roomID, deadline)
+	if err != nil {
+		return entitiesrooms.Poll{}, err
+	}
+
+	s.Schedule(poll)
+	return poll, nil
+}
+
+// Restore ставит таймеры для всех открытых раундов со сроком. Просроченные
+// за время простоя сервера раунды обрабатываются сразу.
+func (s *Service) Restore(ctx context.Context) error {
+	pending, err := s.pollService.GetPending(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, poll := range pending {
+		s.Schedule(poll)
+	}
+	logger.Infof(ctx, "Restored %d poll deadlines", len(pending))
+	return nil
+}
+
+// Schedule ставит таймер на срок голосования раунда, заменяя прежний таймер раунда.
+// Раунд без срока только снимает таймер.
+func (s *Service) Schedule(poll entitiesrooms.Poll) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if timer, ok := s.timers[poll.ID]; ok {
+		timer.Stop()
+		delete(s.timers, poll.ID)
+	}
+	if poll.Deadline.IsZero() {
+		return
+	}
+
+	s.timers[poll.ID] = time.AfterFunc(time.Until(poll.Deadline), func() {
+		s.expire(poll)
+	})
+}
+
+// expire закрывает раунд по таймеру и запускает выбор игры. Если срок перенесли
+// или раунд закрыли раньше, таймер ничего не делает.
+func (s *Service) expire(poll entitiesrooms.Poll) {
+	ctx := context.Background()
+
+	s.mu.Lock()
+	delete(s.timers, poll.ID)
+	s.mu.Unlock()
+
+	closed, err := s.pollService.Expire(ctx, poll.RoomID, poll.ID)
+	if errors.Is(err, servicepolls.ErrPollNotOpen) || errors.Is(err, servicepolls.ErrPollNotFound) {
+		return
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "ExpirePoll by timer error: %v; pollID: %v", err, poll.ID)
+
+		return
+	}
+
+	s.pick(closed, 0)
+}
+
+// pick выбирает игру по раунду, закрытому по сроку, от имени владельца комнаты. Если
+// в комнате идёт другой выбор, попытка повторяется после его окончания. Если выбор
+// не удался, в комнату отправляется poll.pick_failed: голосование уже закрыто,
+// и владелец может запустить выбор вручную.
+func (s *Service) pick(poll entitiesrooms.Poll, attempt int) {
+	ctx := context.Background()
+
+	// Пока ждали повтора, по раунду могли выбрать игру вручную.
+	current, err := s.pollService.Get(ctx, poll.RoomID, poll.ID)
+	if err != nil {
+		logger.Errorf(ctx, "ExpirePoll GetPoll error: %v; pollID: %v", err, poll.ID)
+		s.fail(poll, err)
+
+		return
+	}
+	if current.Status == entitiesrooms.PollStatusPicked {
+		return
+	}
+
+	room, err := s.roomService.GetByID(ctx, poll.RoomID)
+	if err != nil {
+		logger.Errorf(ctx, "ExpirePoll GetByID error: %v; roomID: %v", err, poll.RoomID)
+		s.fail(poll, err)
+
+		return
+	}
+
+	_, err = s.resultService.Spin(ctx, poll.RoomID, room.OwnerID, serviceresults.PickOptions{PollID: poll.ID})
+	if errors.Is(err, serviceresults.ErrSpinInProgress) && attempt < pickRetries {
+		time.AfterFunc(pickRetryDelay, func() {
+			s.pick(poll, attempt+1)
+		})
+
+		return
+	}
+
+	if err != nil {
+		logger.Errorf(ctx, "ExpirePoll auto pick error: %v; roomID: %v", err, poll.RoomID)
+		s.fail(poll, err)
+	}
+}
+
+// fail сообщает комнате, что выбор по сроку не удался.
+func (s *Service) fail(poll entitiesrooms.Poll, err error) {
+	if s.hub == nil {
+		return
+	}
+
+	s.hub.Broadcast(poll.RoomID, hub.RoomEvent{
+		Type:   hub.EventPollPickFailed,
+		RoomID: poll.RoomID,
+		Payload: map[string]any{
+			"poll_id": poll.ID,
+			"error":   err.Error(),
+		},
+	})
+}
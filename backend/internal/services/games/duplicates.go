@@ -0,0 +1,112 @@
+package games
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+// DuplicateError - в комнате уже есть похожие игры. Добавление повторяется с force,
+// если это всё же другая игра.
+type DuplicateError struct {
+	Candidates []entitiesrooms.Game
+}
+
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("room already has %d similar games", len(e.Candidates))
+}
+
+// minContainedTitle - сколько букв должно быть в названии, чтобы считать похожими
+// игры, в названии одной из которых встречаются все слова другой.
+const minContainedTitle = 4
+
+// duplicates возвращает игры комнаты, похожие на game: с той же игрой каталога или
+// с похожим названием (см. similarTitles).
+func (s *Service) duplicates(ctx context.Context, game entitiesrooms.Game) ([]entitiesrooms.Game, error) {
+	existing, err := s.GetAllRoomGames(ctx, game.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	title := normalizeTitle(game.Title)
+	var res []entitiesrooms.Game
+	for _, g := range existing {
+		sameEntry := game.CatalogID != "" && g.CatalogID == game.CatalogID
+		if sameEntry || similarTitles(title, normalizeTitle(g.Title)) {
+			res = append(res, g)
+		}
+	}
+	return res, nil
+}
+
+// normalizeTitle приводит название к нижнему регистру, заменяет знаки препинания
+// пробелами и убирает лишние пробелы: "Catan: Seafarers " и "catan seafarers" совпадают.
+func normalizeTitle(title string) string {
+	title = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, title)
+	return strings.Join(strings.Fields(title), " ")
+}
+
+// similarTitles сравнивает нормализованные названия. Названия похожи, если они совпадают,
+// отличаются опечаткой (расстояние Левенштейна до 1 для коротких и до 2 для названий
+// от 6 символов) или все слова более короткого названия есть в более длинном
+// ("catan" и "settlers of catan").
+func similarTitles(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	shortest := min(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	maxDistance := 1
+	if shortest >= 6 {
+		maxDistance = 2
+	}
+	if levenshtein(a, b) <= maxDistance {
+		return true
+	}
+
+	if utf8.RuneCountInString(a) > utf8.RuneCountInString(b) {
+		a, b = b, a
+	}
+	if utf8.RuneCountInString(strings.ReplaceAll(a, " ", "")) < minContainedTitle {
+		return false
+	}
+	words := strings.Fields(b)
+	for _, w := range strings.Fields(a) {
+		if !slices.Contains(words, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// levenshtein - число вставок, удалений и замен символов, переводящих a в b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
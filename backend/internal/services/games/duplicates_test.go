@@ -0,0 +1,56 @@
+package games
+
+import "testing"
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{name: "already normalized", title: "catan seafarers", want: "catan seafarers"},
+		{name: "case and punctuation", title: "Catan: Seafarers ", want: "catan seafarers"},
+		{name: "repeated separators", title: "  Ticket   to\tRide -- Europe!", want: "ticket to ride europe"},
+		{name: "digits are kept", title: "7 Wonders: Duel", want: "7 wonders duel"},
+		{name: "apostrophes split words", title: "Tzolk'in", want: "tzolk in"},
+		{name: "non-latin letters", title: "Каркассон. Охотники!", want: "каркассон охотники"},
+		{name: "only punctuation", title: " - : ! ", want: ""},
+		{name: "empty", title: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeTitle(tt.title); got != tt.want {
+				t.Errorf("normalizeTitle(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimilarTitles(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{name: "equal", a: "catan", b: "catan", want: true},
+		{name: "one typo in a short title", a: "uno", b: "uni", want: true},
+		{name: "two typos in a short title", a: "uno", b: "ina", want: false},
+		{name: "two typos in a long title", a: "carcassonne", b: "carcasone", want: true},
+		{name: "three typos in a long title", a: "carcassonne", b: "karkasone", want: false},
+		{name: "all words contained", a: "catan", b: "settlers of catan", want: true},
+		{name: "contained title is too short", a: "go", b: "go fish", want: false},
+		{name: "different games", a: "azul", b: "chess", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := similarTitles(tt.a, tt.b); got != tt.want {
+				t.Errorf("similarTitles(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if got := similarTitles(tt.b, tt.a); got != tt.want {
+				t.Errorf("similarTitles(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package games
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+// Export записывает игры в формате format (ImportFormatCSV или ImportFormatJSON)
+// так, чтобы файл можно было импортировать в другую комнату.
+func Export(format string, w io.Writer, games []entitiesrooms.Game) error {
+	switch format {
+	case ImportFormatCSV:
+		return exportCSV(w, games)
+	case ImportFormatJSON:
+		// Пустая комната выгружается пустым массивом, а не null, чтобы файл можно было импортировать.
+		if games == nil {
+			games = make([]entitiesrooms.Game, 0)
+		}
+		return json.NewEncoder(w).Encode(games)
+	default:
+		return ErrUnknownImportFormat
+	}
+}
+
+func exportCSV(w io.Writer, games []entitiesrooms.Game) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvColumns); err != nil {
+		return err
+	}
+
+	for _, g := range games {
+		err := writer.Write([]string{
+			g.Title,
+			strconv.Itoa(g.MinPlayers),
+			strconv.Itoa(g.MaxPlayers),
+			strconv.Itoa(g.DurationMinutes),
+			strings.Join(g.Tags, ";"),
+			g.Link,
+			g.Notes,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,58 @@
+package games
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+func TestExport(t *testing.T) {
+	azul := entitiesrooms.Game{
+		Title:           "Azul",
+		MinPlayers:      2,
+		MaxPlayers:      4,
+		DurationMinutes: 45,
+		Tags:            []string{"abstract", "family"},
+		Link:            "https://example.com/azul",
+		Notes:           "tiles",
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		games  []entitiesrooms.Game
+		want   []entitiesrooms.Game
+	}{
+		{name: "json round trip", format: ImportFormatJSON, games: []entitiesrooms.Game{azul}, want: []entitiesrooms.Game{azul}},
+		{name: "csv round trip", format: ImportFormatCSV, games: []entitiesrooms.Game{azul}, want: []entitiesrooms.Game{azul}},
+		{name: "empty room to json", format: ImportFormatJSON, want: []entitiesrooms.Game{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Export(tt.format, &buf, tt.games); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, err := Parse(tt.format, bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatalf("parse exported %q: %v", buf.String(), err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("exported games = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := Export(ImportFormatJSON, &buf, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Errorf("empty room export = %s, want []", got)
+	}
+}
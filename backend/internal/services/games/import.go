@@ -0,0 +1,387 @@
+package games
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
+	repositorygames "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/games"
+	"github.com/google/uuid"
+)
+
+// Форматы файлов, из которых импортируются игры. В CSV и JSON игры также экспортируются.
+const (
+	ImportFormatBGG   = "bgg"
+	ImportFormatSteam = "steam"
+	ImportFormatCSV   = "csv"
+	ImportFormatJSON  = "json"
+)
+
+// csvColumns - столбцы CSV с играми. Метки в столбце tags разделяются точкой с запятой.
+var csvColumns = []string{"title", "min_players", "max_players", "duration_minutes", "tags", "link", "notes"}
+
+// MaxImportGames - сколько игр можно импортировать одним файлом.
+const MaxImportGames = 2000
+
+var (
+	ErrUnknownImportFormat = errors.New("unknown import format")
+	ErrTooManyImportGames  = errors.New("too many games to import")
+)
+
+// ImportSkipped - игра из файла, которая не была добавлена, потому что уже есть в комнате
+// или выше в файле. Row - её номер в файле с 1 (без строки заголовка CSV).
+type ImportSkipped struct {
+	Row    int    `json:"row"`
+	Title  string `json:"title"`
+	Reason string `json:"reason"`
+}
+
+// ImportResult - итог импорта: добавленные игры и пропущенные с причиной.
+type ImportResult struct {
+	Added   []entitiesrooms.Game `json:"added"`
+	Skipped []ImportSkipped      `json:"skipped"`
+}
+
+// ImportRowError - ошибка в игре из файла. Row нумеруется так же, как в ImportSkipped.
+type ImportRowError struct {
+	Row   int    `json:"row"`
+	Title string `json:"title"`
+	Error string `json:"error"`
+}
+
+// ImportError - в файле есть некорректные игры; в этом случае не добавляется ни одна.
+type ImportError struct {
+	Rows []ImportRowError
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("import file has %d invalid rows", len(e.Rows))
+}
+
+// Parse разбирает файл формата format в описания игр. ID и RoomID у игр не заполнены.
+func Parse(format string, r io.Reader) ([]entitiesrooms.Game, error) {
+	var (
+		games []entitiesrooms.Game
+		err   error
+	)
+	switch format {
+	case ImportFormatBGG:
+		games, err = parseBGG(r)
+	case ImportFormatSteam:
+		games, err = parseSteam(r)
+	case ImportFormatCSV:
+		games, err = parseCSV(r)
+	case ImportFormatJSON:
+		games, err = parseJSON(r)
+	default:
+		return nil, ErrUnknownImportFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(games) > MaxImportGames {
+		return nil, ErrTooManyImportGames
+	}
+	return games, nil
+}
+
+// bggCollection - выгрузка коллекции BoardGameGeek (XML API2 /collection?stats=1).
+type bggCollection struct {
+	Items []struct {
+		ObjectID string `xml:"objectid,attr"`
+		Subtype  string `xml:"subtype,attr"`
+		Name     string `xml:"name"`
+		Stats    *struct {
+			MinPlayers  string `xml:"minplayers,attr"`
+			MaxPlayers  string `xml:"maxplayers,attr"`
+			PlayingTime string `xml:"playingtime,attr"`
+		} `xml:"stats"`
+		Status *struct {
+			Own string `xml:"own,attr"`
+		} `xml:"status"`
+	} `xml:"item"`
+}
+
+// parseBGG берёт из коллекции BGG игры, которыми владеют (дополнения пропускаются):
+// название, число игроков, время партии и ссылку на страницу игры.
+func parseBGG(r io.Reader) ([]entitiesrooms.Game, error) {
+	var collection bggCollection
+	if err := xml.NewDecoder(r).Decode(&collection); err != nil {
+		return nil, fmt.Errorf("parse bgg collection: %w", err)
+	}
+
+	games := make([]entitiesrooms.Game, 0, len(collection.Items))
+	for _, it := range collection.Items {
+		if it.Subtype == "boardgameexpansion" || (it.Status != nil && it.Status.Own != "1") {
+			continue
+		}
+
+		game := entitiesrooms.Game{
+			Title: strings.TrimSpace(it.Name),
+			Tags:  []string{},
+		}
+		if it.Stats != nil {
+			game.MinPlayers = atoi(it.Stats.MinPlayers)
+			game.MaxPlayers = atoi(it.Stats.MaxPlayers)
+			game.DurationMinutes = atoi(it.Stats.PlayingTime)
+		}
+		if it.ObjectID != "" {
+			game.Link = "https://boardgamegeek.com/boardgame/" + it.ObjectID
+		}
+		games = append(games, game)
+	}
+	return games, nil
+}
+
+// steamOwnedGames - ответ Steam Web API IPlayerService/GetOwnedGames?include_appinfo=1.
+type steamOwnedGames struct {
+	Response struct {
+		Games []struct {
+			AppID           int    `json:"appid"`
+			Name            string `json:"name"`
+			PlaytimeForever int    `json:"playtime_forever"`
+		} `json:"games"`
+	} `json:"response"`
+}
+
+// parseSteam берёт из списка игр Steam название и ссылку на страницу в магазине.
+// Steam не сообщает ни число игроков, ни длительность партии, поэтому они не заполняются,
+// а наигранное владельцем время записывается в заметки.
+func parseSteam(r io.Reader) ([]entitiesrooms.Game, error) {
+	var owned steamOwnedGames
+	if err := json.NewDecoder(r).Decode(&owned); err != nil {
+		return nil, fmt.Errorf("parse steam owned games: %w", err)
+	}
+
+	games := make([]entitiesrooms.Game, 0, len(owned.Response.Games))
+	for _, it := range owned.Response.Games {
+		game := entitiesrooms.Game{
+			Title: strings.TrimSpace(it.Name),
+			Tags:  []string{"steam"},
+		}
+		if it.AppID > 0 {
+			game.Link = "https://store.steampowered.com/app/" + strconv.Itoa(it.AppID)
+		}
+		if it.PlaytimeForever > 0 {
+			game.Notes = fmt.Sprintf("Steam playtime: %d h", (it.PlaytimeForever+30)/60)
+		}
+		games = append(games, game)
+	}
+	return games, nil
+}
+
+// parseCSV читает CSV со строкой заголовка из столбцов csvColumns в любом порядке;
+// обязателен только title. Нечисловые значения в числовых столбцах - ошибка строки.
+func parseCSV(r io.Reader) ([]entitiesrooms.Game, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(csvColumns, name) {
+			return nil, fmt.Errorf("unknown csv column %q", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("csv has no title column")
+	}
+
+	var (
+		games     []entitiesrooms.Game
+		rowErrors []ImportRowError
+	)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		game := entitiesrooms.Game{
+			Title: field("title"),
+			Tags: strings.FieldsFunc(field("tags"), func(r rune) bool {
+				return r == ';' || r == ','
+			}),
+			Link:  field("link"),
+			Notes: field("notes"),
+		}
+		for _, column := range []struct {
+			name  string
+			value *int
+		}{
+			{"min_players", &game.MinPlayers},
+			{"max_players", &game.MaxPlayers},
+			{"duration_minutes", &game.DurationMinutes},
+		} {
+			raw := field(column.name)
+			if raw == "" {
+				continue
+			}
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				rowErrors = append(rowErrors, ImportRowError{
+					Row:   len(games) + 1,
+					Title: game.Title,
+					Error: column.name + " must be an integer",
+				})
+				break
+			}
+			*column.value = n
+		}
+		games = append(games, game)
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, &ImportError{Rows: rowErrors}
+	}
+	return games, nil
+}
+
+// importGame - игра в JSON-файле; так же выглядит экспорт, лишние поля игнорируются.
+type importGame struct {
+	Title           string   `json:"title"`
+	MinPlayers      int      `json:"min_players"`
+	MaxPlayers      int      `json:"max_players"`
+	DurationMinutes int      `json:"duration_minutes"`
+	Tags            []string `json:"tags"`
+	Link            string   `json:"link"`
+	Notes           string   `json:"notes"`
+}
+
+// parseJSON читает JSON-массив игр.
+func parseJSON(r io.Reader) ([]entitiesrooms.Game, error) {
+	var items []importGame
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("parse json games: %w", err)
+	}
+
+	games := make([]entitiesrooms.Game, 0, len(items))
+	for _, it := range items {
+		games = append(games, entitiesrooms.Game{
+			Title:           it.Title,
+			MinPlayers:      it.MinPlayers,
+			MaxPlayers:      it.MaxPlayers,
+			DurationMinutes: it.DurationMinutes,
+			Tags:            it.Tags,
+			Link:            strings.TrimSpace(it.Link),
+			Notes:           it.Notes,
+		})
+	}
+	return games, nil
+}
+
+// Import добавляет в комнату игры из файла от имени userID одной транзакцией и рассылает
+// одно событие games.bulk_added. Игры, название которых после normalizeTitle совпадает
+// с игрой комнаты или игрой выше в файле, пропускаются. Если хотя бы одна игра не прошла
+// проверку, возвращается *ImportError со всеми ошибками и не добавляется ни одна игра.
+func (s *Service) Import(ctx context.Context, roomID, userID string, games []entitiesrooms.Game) (ImportResult, error) {
+	existing, err := s.GetAllRoomGames(ctx, roomID)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	seen := make(map[string]bool, len(existing)+len(games))
+	for _, g := range existing {
+		seen[normalizeTitle(g.Title)] = true
+	}
+
+	res := ImportResult{Added: []entitiesrooms.Game{}, Skipped: []ImportSkipped{}}
+	var (
+		toAdd     []entitiesrooms.Game
+		rowErrors []ImportRowError
+	)
+	for i, game := range games {
+		game.ID = uuid.New().String()
+		game.RoomID = roomID
+		game.Title = strings.TrimSpace(game.Title)
+		game.Tags = entitiesrooms.NormalizeTags(game.Tags)
+
+		if msg := Validate(game); msg != "" {
+			rowErrors = append(rowErrors, ImportRowError{Row: i + 1, Title: game.Title, Error: msg})
+			continue
+		}
+		key := normalizeTitle(game.Title)
+		if seen[key] {
+			res.Skipped = append(res.Skipped, ImportSkipped{
+				Row:    i + 1,
+				Title:  game.Title,
+				Reason: "Game already exists in the room",
+			})
+			continue
+		}
+		seen[key] = true
+		toAdd = append(toAdd, game)
+	}
+	if len(rowErrors) > 0 {
+		return ImportResult{}, &ImportError{Rows: rowErrors}
+	}
+	if len(toAdd) == 0 {
+		return res, nil
+	}
+
+	// Записи каталога заносятся до транзакции: если она не пройдёт, они останутся
+	// в каталоге, как и после удаления игры из комнаты.
+	params := make([]repositorygames.AddParams, 0, len(toAdd))
+	for _, game := range toAdd {
+		p, err := s.addParams(ctx, game, userID)
+		if err != nil {
+			return ImportResult{}, err
+		}
+		params = append(params, p)
+	}
+
+	added, err := s.repo.AddMany(ctx, params)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	res.Added = added
+
+	if s.hub != nil {
+		items := make([]map[string]any, 0, len(added))
+		for _, g := range added {
+			items = append(items, payload(g))
+		}
+		s.hub.Broadcast(roomID, hub.RoomEvent{
+			Type:   hub.EventGamesBulkAdded,
+			RoomID: roomID,
+			Payload: map[string]any{
+				"games": items,
+			},
+		})
+	}
+	return res, nil
+}
+
+func atoi(s string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
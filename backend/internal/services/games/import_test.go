@@ -0,0 +1,316 @@
+package games
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+func TestParseBGG(t *testing.T) {
+	tests := []struct {
+		name    string
+		xml     string
+		want    []entitiesrooms.Game
+		wantErr bool
+	}{
+		{
+			name: "owned games with stats",
+			xml: `<items>
+	<item objectid="230802" subtype="boardgame">
+		<name> Azul </name>
+		<stats minplayers="2" maxplayers="4" playingtime="45"/>
+		<status own="1"/>
+	</item>
+	<item objectid="13" subtype="boardgame">
+		<name>Catan</name>
+	</item>
+</items>`,
+			want: []entitiesrooms.Game{
+				{
+					Title:           "Azul",
+					MinPlayers:      2,
+					MaxPlayers:      4,
+					DurationMinutes: 45,
+					Tags:            []string{},
+					Link:            "https://boardgamegeek.com/boardgame/230802",
+				},
+				{Title: "Catan", Tags: []string{}, Link: "https://boardgamegeek.com/boardgame/13"},
+			},
+		},
+		{
+			name: "expansions and games not owned are skipped",
+			xml: `<items>
+	<item objectid="1" subtype="boardgameexpansion"><name>Seafarers</name><status own="1"/></item>
+	<item objectid="2" subtype="boardgame"><name>Wishlist</name><status own="0"/></item>
+</items>`,
+			want: []entitiesrooms.Game{},
+		},
+		{
+			name: "invalid numbers are left unset",
+			xml: `<items>
+	<item objectid="3" subtype="boardgame"><name>Uno</name><stats minplayers="-1" maxplayers="many" playingtime=""/></item>
+</items>`,
+			want: []entitiesrooms.Game{{Title: "Uno", Tags: []string{}, Link: "https://boardgamegeek.com/boardgame/3"}},
+		},
+		{
+			name:    "not xml",
+			xml:     `{"items":[]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBGG(strings.NewReader(tt.xml))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseBGG() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseBGG() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSteam(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    []entitiesrooms.Game
+		wantErr bool
+	}{
+		{
+			name: "owned games",
+			json: `{"response":{"game_count":2,"games":[
+				{"appid":620,"name":" Portal 2 ","playtime_forever":95},
+				{"appid":0,"name":"Unknown","playtime_forever":0}
+			]}}`,
+			want: []entitiesrooms.Game{
+				{
+					Title: "Portal 2",
+					Tags:  []string{"steam"},
+					Link:  "https://store.steampowered.com/app/620",
+					Notes: "Steam playtime: 2 h",
+				},
+				{Title: "Unknown", Tags: []string{"steam"}},
+			},
+		},
+		{
+			name: "playtime is rounded to the nearest hour",
+			json: `{"response":{"games":[{"appid":1,"name":"Short","playtime_forever":89}]}}`,
+			want: []entitiesrooms.Game{{
+				Title: "Short",
+				Tags:  []string{"steam"},
+				Link:  "https://store.steampowered.com/app/1",
+				Notes: "Steam playtime: 1 h",
+			}},
+		},
+		{
+			name: "empty library",
+			json: `{"response":{}}`,
+			want: []entitiesrooms.Game{},
+		},
+		{
+			name:    "not json",
+			json:    `<games/>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSteam(strings.NewReader(tt.json))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseSteam() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSteam() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		want    []entitiesrooms.Game
+		rows    []ImportRowError
+		wantErr bool
+	}{
+		{
+			name: "all columns",
+			csv: "title,min_players,max_players,duration_minutes,tags,link,notes\n" +
+				"Azul,2,4,45,abstract;family,https://example.com/azul,tiles\n",
+			want: []entitiesrooms.Game{{
+				Title:           "Azul",
+				MinPlayers:      2,
+				MaxPlayers:      4,
+				DurationMinutes: 45,
+				Tags:            []string{"abstract", "family"},
+				Link:            "https://example.com/azul",
+				Notes:           "tiles",
+			}},
+		},
+		{
+			name: "columns in any order with bom and missing values",
+			csv:  "\ufeffNotes, Title ,max_players\n,Catan,\nquick,Uno,10\n",
+			want: []entitiesrooms.Game{
+				{Title: "Catan", Tags: []string{}},
+				{Title: "Uno", MaxPlayers: 10, Notes: "quick", Tags: []string{}},
+			},
+		},
+		{
+			name: "short rows leave columns empty",
+			csv:  "title,tags,link\nChess\n",
+			want: []entitiesrooms.Game{{Title: "Chess", Tags: []string{}}},
+		},
+		{
+			name: "non-numeric values are reported per row",
+			csv: "title,min_players,max_players,duration_minutes\n" +
+				"Azul,2,4,45\n" +
+				"Catan,three,4,60\n" +
+				"Uno,2,4,long\n",
+			rows: []ImportRowError{
+				{Row: 2, Title: "Catan", Error: "min_players must be an integer"},
+				{Row: 3, Title: "Uno", Error: "duration_minutes must be an integer"},
+			},
+		},
+		{
+			name: "only the first bad column of a row is reported",
+			csv:  "title,min_players,max_players\nCatan,x,y\n",
+			rows: []ImportRowError{
+				{Row: 1, Title: "Catan", Error: "min_players must be an integer"},
+			},
+		},
+		{
+			name:    "unknown column",
+			csv:     "title,rating\nAzul,8\n",
+			wantErr: true,
+		},
+		{
+			name:    "no title column",
+			csv:     "min_players,max_players\n2,4\n",
+			wantErr: true,
+		},
+		{
+			name:    "empty file",
+			csv:     "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCSV(strings.NewReader(tt.csv))
+			if tt.rows != nil {
+				var importErr *ImportError
+				if !errors.As(err, &importErr) {
+					t.Fatalf("err = %v, want *ImportError", err)
+				}
+				if !reflect.DeepEqual(importErr.Rows, tt.rows) {
+					t.Errorf("rows = %+v, want %+v", importErr.Rows, tt.rows)
+				}
+				return
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseCSV() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCSV() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    []entitiesrooms.Game
+		wantErr bool
+	}{
+		{
+			name: "export format with extra fields",
+			json: `[{"id":"1","room_id":"r","title":"Azul","min_players":2,"max_players":4,"duration_minutes":45,"tags":["family"],"link":" https://example.com/azul ","notes":"tiles"}]`,
+			want: []entitiesrooms.Game{{
+				Title:           "Azul",
+				MinPlayers:      2,
+				MaxPlayers:      4,
+				DurationMinutes: 45,
+				Tags:            []string{"family"},
+				Link:            "https://example.com/azul",
+				Notes:           "tiles",
+			}},
+		},
+		{
+			name: "empty array",
+			json: `[]`,
+			want: []entitiesrooms.Game{},
+		},
+		{
+			name:    "not an array",
+			json:    `{"title":"Azul"}`,
+			wantErr: true,
+		},
+		{
+			name:    "wrong field type",
+			json:    `[{"title":"Azul","min_players":"two"}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseJSON(strings.NewReader(tt.json))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseJSON() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseJSON() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLimitsGames(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("title\n")
+	for range MaxImportGames + 1 {
+		b.WriteString("Game\n")
+	}
+
+	if _, err := Parse(ImportFormatCSV, strings.NewReader(b.String())); !errors.Is(err, ErrTooManyImportGames) {
+		t.Errorf("err = %v, want %v", err, ErrTooManyImportGames)
+	}
+	if _, err := Parse("xlsx", strings.NewReader("")); !errors.Is(err, ErrUnknownImportFormat) {
+		t.Errorf("err = %v, want %v", err, ErrUnknownImportFormat)
+	}
+}
@@ -2,64 +2,266 @@ package games
 
 import (
 	"context"
+	"errors"
 
 	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
 	repositorygames "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/games"
+	servicecatalog "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/catalog"
+	servicelibrary "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/library"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
 	"github.com/google/uuid"
 )
 
+var (
+	ErrGameNotFound  = errors.New("game not found")
+	ErrMergeSameGame = errors.New("cannot merge a game into itself")
+)
+
 type GameService interface {
-	Add(context.Context, entitiesrooms.Game) (entitiesrooms.Game, error)
+	Add(context.Context, entitiesrooms.Game, string, bool) (entitiesrooms.Game, error)
+	AddFromCatalog(context.Context, string, string, string, bool) (entitiesrooms.Game, error)
+	AddFromLibrary(context.Context, string, string, string, bool) (entitiesrooms.Game, error)
+	Merge(context.Context, string, string, string) (entitiesrooms.Game, error)
 	GetAllRoomGames(context.Context, string) ([]entitiesrooms.Game, error)
 	Delete(context.Context, string, string) error
+	Restore(context.Context, string, string) (entitiesrooms.Game, error)
+	GetArchived(context.Context, string) ([]entitiesrooms.Game, error)
 	Get(context.Context, string) (entitiesrooms.Game, error)
+	Update(context.Context, entitiesrooms.Game) (entitiesrooms.Game, error)
+	Import(context.Context, string, string, []entitiesrooms.Game) (ImportResult, error)
 }
 
 type Service struct {
-	repo repositorygames.GameRepository
-	hub  hub.Hub
+	repo           repositorygames.GameRepository
+	catalogService servicecatalog.CatalogService
+	libraryService servicelibrary.LibraryService
+	hub            hub.Hub
 }
 
-func NewService(repo repositorygames.GameRepository) *Service {
-	return &Service{repo: repo}
+func NewService(
+	repo repositorygames.GameRepository,
+	catalogService servicecatalog.CatalogService,
+	libraryService servicelibrary.LibraryService,
+) *Service {
+	return &Service{repo: repo, catalogService: catalogService, libraryService: libraryService}
 }
 
 func (s *Service) SetHub(h hub.Hub) {
 	s.hub = h
 }
 
-func (s *Service) Add(ctx context.Context, game entitiesrooms.Game) (entitiesrooms.Game, error) {
+// Add добавляет игру в комнату. Если игра не привязана к каталогу, она привязывается
+// к каталожной игре с тем же названием, а если такой нет - заносится в каталог от имени userID.
+// Если в комнате есть похожие игры, возвращается *DuplicateError, а игра добавляется только с force.
+func (s *Service) Add(ctx context.Context, game entitiesrooms.Game, userID string, force bool) (entitiesrooms.Game, error) {
+	if !force {
+		candidates, err := s.duplicates(ctx, game)
+		if err != nil {
+			return entitiesrooms.Game{}, err
+		}
+		if len(candidates) > 0 {
+			return entitiesrooms.Game{}, &DuplicateError{Candidates: candidates}
+		}
+	}
+
+	params, err := s.addParams(ctx, game, userID)
+	if err != nil {
+		return entitiesrooms.Game{}, err
+	}
+
+	gameRes, err := s.repo.Add(ctx, params)
+	if err == nil && s.hub != nil {
+		s.hub.Broadcast(game.RoomID, hub.RoomEvent{
+			Type:    hub.EventGameAdded,
+			RoomID:  game.RoomID,
+			Payload: payload(gameRes),
+		})
+	}
+	return gameRes, err
+}
+
+// addParams готовит параметры добавления игры, при необходимости занося её в каталог.
+func (s *Service) addParams(ctx context.Context, game entitiesrooms.Game, userID string) (repositorygames.AddParams, error) {
+	if game.CatalogID == "" {
+		entry, err := s.catalogService.Ensure(ctx, game, userID)
+		if err != nil {
+			return repositorygames.AddParams{}, err
+		}
+		game.CatalogID = entry.ID
+	}
+
 	id, err := uuid.Parse(game.ID)
 	if err != nil {
 		logger.Errorf(ctx, "AddGame invalid ID: %v", err)
 
-		return entitiesrooms.Game{}, err
+		return repositorygames.AddParams{}, err
 	}
 
 	roomID, err := uuid.Parse(game.RoomID)
 	if err != nil {
 		logger.Errorf(ctx, "AddGame invalid RoomID: %v", err)
 
+		return repositorygames.AddParams{}, err
+	}
+
+	catalogID, err := uuid.Parse(game.CatalogID)
+	if err != nil {
+		logger.Errorf(ctx, "AddGame invalid CatalogID: %v", err)
+
+		return repositorygames.AddParams{}, err
+	}
+
+	broughtBy, err := nullUUID(game.BroughtBy)
+	if err != nil {
+		logger.Errorf(ctx, "AddGame invalid BroughtBy: %v", err)
+
+		return repositorygames.AddParams{}, err
+	}
+
+	return repositorygames.AddParams{
+		ID:              id,
+		RoomID:          roomID,
+		Title:           game.Title,
+		MinPlayers:      int32(game.MinPlayers),
+		MaxPlayers:      int32(game.MaxPlayers),
+		DurationMinutes: int32(game.DurationMinutes),
+		Tags:            game.Tags,
+		Link:            game.Link,
+		Notes:           game.Notes,
+		CatalogID:       uuid.NullUUID{UUID: catalogID, Valid: true},
+		BroughtBy:       broughtBy,
+	}, nil
+}
+
+// AddFromCatalog добавляет в комнату игру каталога, копируя её описание;
+// broughtBy - участник, который приносит игру (может быть пустым).
+// Если игры нет в каталоге, возвращается servicecatalog.ErrCatalogGameNotFound.
+func (s *Service) AddFromCatalog(ctx context.Context, roomID, catalogID, broughtBy string, force bool) (entitiesrooms.Game, error) {
+	entry, err := s.catalogService.Get(ctx, catalogID)
+	if err != nil {
+		return entitiesrooms.Game{}, err
+	}
+
+	return s.Add(ctx, entitiesrooms.Game{
+		ID:              uuid.New().String(),
+		RoomID:          roomID,
+		Title:           entry.Title,
+		MinPlayers:      entry.MinPlayers,
+		MaxPlayers:      entry.MaxPlayers,
+		DurationMinutes: entry.DurationMinutes,
+		Tags:            entry.Tags,
+		Link:            entry.Link,
+		CatalogID:       entry.ID,
+		BroughtBy:       broughtBy,
+	}, "", force)
+}
+
+// AddFromLibrary добавляет в комнату игру из библиотеки пользователя userID вместе с заметками;
+// игру приносит сам пользователь.
+// Если игры нет в его библиотеке, возвращается servicelibrary.ErrLibraryGameNotFound.
+func (s *Service) AddFromLibrary(ctx context.Context, roomID, userID, libraryGameID string, force bool) (entitiesrooms.Game, error) {
+	entry, err := s.libraryService.Get(ctx, libraryGameID, userID)
+	if err != nil {
 		return entitiesrooms.Game{}, err
 	}
 
-	gameRes, err := s.repo.Add(ctx, repositorygames.AddParams{
-		ID:     id,
-		RoomID: roomID,
-		Title:  game.Title,
+	game := entry.Game()
+	game.ID = uuid.New().String()
+	game.RoomID = roomID
+	game.BroughtBy = userID
+
+	return s.Add(ctx, game, userID, force)
+}
+
+// Merge переносит голоса и историю игры sourceID на игру targetID той же комнаты
+// и убирает исходную игру в архив со ссылкой на целевую (merged_into). Возвращает целевую игру;
+// если одной из игр нет в комнате - ErrGameNotFound. Архивные игры не сливаются.
+func (s *Service) Merge(ctx context.Context, roomID, sourceID, targetID string) (entitiesrooms.Game, error) {
+	if sourceID == targetID {
+		return entitiesrooms.Game{}, ErrMergeSameGame
+	}
+
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "MergeGames invalid RoomID: %v", err)
+
+		return entitiesrooms.Game{}, err
+	}
+
+	var games [2]entitiesrooms.Game
+	var ids [2]uuid.UUID
+	for i, id := range []string{sourceID, targetID} {
+		ids[i], err = uuid.Parse(id)
+		if err != nil {
+			return entitiesrooms.Game{}, ErrGameNotFound
+		}
+
+		games[i], err = s.repo.Get(ctx, ids[i])
+		if err != nil {
+			return entitiesrooms.Game{}, err
+		}
+		if games[i].RoomID != roomID || games[i].IsArchived() {
+			return entitiesrooms.Game{}, ErrGameNotFound
+		}
+	}
+
+	err = s.repo.Merge(ctx, repositorygames.MergeParams{
+		RoomID:   uuidRoomID,
+		SourceID: ids[0],
+		TargetID: ids[1],
 	})
-	if err == nil && s.hub != nil {
-		s.hub.Broadcast(game.RoomID, hub.RoomEvent{
-			Type:   hub.EventGameAdded,
-			RoomID: game.RoomID,
+	if err != nil {
+		return entitiesrooms.Game{}, err
+	}
+
+	if s.hub != nil {
+		s.hub.Broadcast(roomID, hub.RoomEvent{
+			Type:   hub.EventGamesMerged,
+			RoomID: roomID,
 			Payload: map[string]any{
-				"id":    gameRes.ID,
-				"title": gameRes.Title,
+				"source_id": sourceID,
+				"target_id": targetID,
 			},
 		})
 	}
+	return games[1], nil
+}
+
+// Update перезаписывает название, описание игры и того, кто её приносит.
+func (s *Service) Update(ctx context.Context, game entitiesrooms.Game) (entitiesrooms.Game, error) {
+	id, err := uuid.Parse(game.ID)
+	if err != nil {
+		logger.Errorf(ctx, "UpdateGame invalid ID: %v", err)
+
+		return entitiesrooms.Game{}, err
+	}
+
+	broughtBy, err := nullUUID(game.BroughtBy)
+	if err != nil {
+		logger.Errorf(ctx, "UpdateGame invalid BroughtBy: %v", err)
+
+		return entitiesrooms.Game{}, err
+	}
+
+	gameRes, err := s.repo.Update(ctx, repositorygames.UpdateParams{
+		ID:              id,
+		Title:           game.Title,
+		MinPlayers:      int32(game.MinPlayers),
+		MaxPlayers:      int32(game.MaxPlayers),
+		DurationMinutes: int32(game.DurationMinutes),
+		Tags:            game.Tags,
+		Link:            game.Link,
+		Notes:           game.Notes,
+		BroughtBy:       broughtBy,
+	})
+	if err == nil && gameRes.ID != "" && s.hub != nil {
+		s.hub.Broadcast(gameRes.RoomID, hub.RoomEvent{
+			Type:    hub.EventGameUpdated,
+			RoomID:  gameRes.RoomID,
+			Payload: payload(gameRes),
+		})
+	}
 	return gameRes, err
 }
 
@@ -74,6 +276,8 @@ func (s *Service) GetAllRoomGames(ctx context.Context, roomID string) ([]entitie
 	return s.repo.GetAllRoomGames(ctx, uuidRoomID)
 }
 
+// Delete убирает игру в архив: она пропадает из списка игр и розыгрышей, но результаты
+// выборов продолжают на неё ссылаться. Если игры нет или она уже в архиве, возвращается ErrGameNotFound.
 func (s *Service) Delete(ctx context.Context, id string, roomID string) error {
 	uuidId, err := uuid.Parse(id)
 	if err != nil {
@@ -82,8 +286,15 @@ func (s *Service) Delete(ctx context.Context, id string, roomID string) error {
 		return err
 	}
 
-	err = s.repo.Delete(ctx, uuidId)
-	if err == nil && s.hub != nil {
+	archived, err := s.repo.Archive(ctx, uuidId)
+	if err != nil {
+		return err
+	}
+	if archived.ID == "" {
+		return ErrGameNotFound
+	}
+
+	if s.hub != nil {
 		s.hub.Broadcast(roomID, hub.RoomEvent{
 			Type:   hub.EventGameDeleted,
 			RoomID: roomID,
@@ -92,7 +303,52 @@ func (s *Service) Delete(ctx context.Context, id string, roomID string) error {
 			},
 		})
 	}
-	return err
+	return nil
+}
+
+// Restore возвращает архивную игру комнаты roomID в список игр. Если такой архивной игры
+// в комнате нет, возвращается ErrGameNotFound.
+func (s *Service) Restore(ctx context.Context, id, roomID string) (entitiesrooms.Game, error) {
+	uuidId, err := uuid.Parse(id)
+	if err != nil {
+		return entitiesrooms.Game{}, ErrGameNotFound
+	}
+
+	game, err := s.repo.Get(ctx, uuidId)
+	if err != nil {
+		return entitiesrooms.Game{}, err
+	}
+	if game.RoomID != roomID || !game.IsArchived() {
+		return entitiesrooms.Game{}, ErrGameNotFound
+	}
+
+	restored, err := s.repo.Restore(ctx, uuidId)
+	if err != nil {
+		return entitiesrooms.Game{}, err
+	}
+	if restored.ID == "" {
+		return entitiesrooms.Game{}, ErrGameNotFound
+	}
+
+	if s.hub != nil {
+		s.hub.Broadcast(roomID, hub.RoomEvent{
+			Type:    hub.EventGameRestored,
+			RoomID:  roomID,
+			Payload: payload(restored),
+		})
+	}
+	return restored, nil
+}
+
+func (s *Service) GetArchived(ctx context.Context, roomID string) ([]entitiesrooms.Game, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "GetArchivedGames invalid RoomID: %v", err)
+
+		return nil, err
+	}
+
+	return s.repo.GetArchived(ctx, uuidRoomID)
 }
 
 func (s *Service) Get(ctx context.Context, id string) (entitiesrooms.Game, error) {
@@ -105,3 +361,31 @@ func (s *Service) Get(ctx context.Context, id string) (entitiesrooms.Game, error
 
 	return s.repo.Get(ctx, uuidId)
 }
+
+func payload(game entitiesrooms.Game) map[string]any {
+	return map[string]any{
+		"id":               game.ID,
+		"title":            game.Title,
+		"min_players":      game.MinPlayers,
+		"max_players":      game.MaxPlayers,
+		"duration_minutes": game.DurationMinutes,
+		"tags":             game.Tags,
+		"link":             game.Link,
+		"notes":            game.Notes,
+		"catalog_id":       game.CatalogID,
+		"brought_by":       game.BroughtBy,
+	}
+}
+
+// nullUUID разбирает необязательный ID: пустая строка означает NULL.
+func nullUUID(id string) (uuid.NullUUID, error) {
+	if id == "" {
+		return uuid.NullUUID{}, nil
+	}
+
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+	return uuid.NullUUID{UUID: parsed, Valid: true}, nil
+}
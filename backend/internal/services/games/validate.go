@@ -0,0 +1,50 @@
+package games
+
+import (
+	"net/url"
+	"unicode/utf8"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+// Validate возвращает текст ошибки для некорректного описания игры или пустую строку.
+// Состав и длительность 0 означают, что значение не указано.
+func Validate(game entitiesrooms.Game) string {
+	if game.Title == "" {
+		return "Title is required"
+	}
+
+	if game.MinPlayers < 0 || game.MaxPlayers < 0 {
+		return "Player count must not be negative"
+	}
+
+	if game.MinPlayers > 0 && game.MaxPlayers > 0 && game.MinPlayers > game.MaxPlayers {
+		return "Min players must not exceed max players"
+	}
+
+	if game.DurationMinutes < 0 {
+		return "Duration must not be negative"
+	}
+
+	if len(game.Tags) > entitiesrooms.MaxGameTags {
+		return "Too many tags"
+	}
+
+	for _, tag := range game.Tags {
+		if utf8.RuneCountInString(tag) > entitiesrooms.MaxGameTagLength {
+			return "Tag is too long"
+		}
+	}
+
+	if game.Link != "" {
+		link, err := url.ParseRequestURI(game.Link)
+		if err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" {
+			return "Link must be an http(s) URL"
+		}
+	}
+
+	if utf8.RuneCountInString(game.Notes) > entitiesrooms.MaxGameNotesLength {
+		return "Notes are too long"
+	}
+	return ""
+}
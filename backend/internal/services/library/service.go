@@ -0,0 +1,168 @@
+package library
+
+import (
+	"context"
+	"errors"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/profile"
+	repositorylibrary "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/library"
+	servicecatalog "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/catalog"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrLibraryGameNotFound = errors.New("library game not found")
+	ErrLibraryDuplicate    = errors.New("game is already in the library")
+)
+
+type LibraryService interface {
+	Add(context.Context, profile.LibraryGame) (profile.LibraryGame, error)
+	Get(context.Context, string, string) (profile.LibraryGame, error)
+	GetAll(context.Context, string) ([]profile.LibraryGame, error)
+	Update(context.Context, profile.LibraryGame) (profile.LibraryGame, error)
+	Delete(context.Context, string, string) error
+	GetRoomOwners(context.Context, string) ([]profile.GameOwners, error)
+}
+
+type Service struct {
+	repo           repositorylibrary.LibraryRepository
+	catalogService servicecatalog.CatalogService
+}
+
+func NewService(repo repositorylibrary.LibraryRepository, catalogService servicecatalog.CatalogService) *Service {
+	return &Service{repo: repo, catalogService: catalogService}
+}
+
+// Add добавляет игру в библиотеку пользователя game.UserID, привязывая её к каталогу
+// так же, как игру комнаты. Если эта игра каталога уже есть в библиотеке, возвращается ErrLibraryDuplicate.
+func (s *Service) Add(ctx context.Context, game profile.LibraryGame) (profile.LibraryGame, error) {
+	id, err := uuid.Parse(game.ID)
+	if err != nil {
+		logger.Errorf(ctx, "AddLibraryGame invalid ID: %v", err)
+
+		return profile.LibraryGame{}, err
+	}
+
+	userID, err := uuid.Parse(game.UserID)
+	if err != nil {
+		logger.Errorf(ctx, "AddLibraryGame invalid UserID: %v", err)
+
+		return profile.LibraryGame{}, err
+	}
+
+	entry, err := s.catalogService.Ensure(ctx, game.Game(), game.UserID)
+	if err != nil {
+		return profile.LibraryGame{}, err
+	}
+
+	library, err := s.repo.GetAll(ctx, userID)
+	if err != nil {
+		return profile.LibraryGame{}, err
+	}
+	for _, g := range library {
+		if g.CatalogID == entry.ID {
+			return profile.LibraryGame{}, ErrLibraryDuplicate
+		}
+	}
+
+	return s.repo.Add(ctx, repositorylibrary.AddParams{
+		ID:              id,
+		UserID:          userID,
+		CatalogID:       uuid.NullUUID{UUID: uuid.MustParse(entry.ID), Valid: true},
+		Title:           game.Title,
+		MinPlayers:      int32(game.MinPlayers),
+		MaxPlayers:      int32(game.MaxPlayers),
+		DurationMinutes: int32(game.DurationMinutes),
+		Tags:            game.Tags,
+		Link:            game.Link,
+		Notes:           game.Notes,
+	})
+}
+
+// Get возвращает игру библиотеки пользователя userID. Чужие и несуществующие игры
+// одинаково дают ErrLibraryGameNotFound.
+func (s *Service) Get(ctx context.Context, id, userID string) (profile.LibraryGame, error) {
+	uuidID, err := uuid.Parse(id)
+	if err != nil {
+		return profile.LibraryGame{}, ErrLibraryGameNotFound
+	}
+
+	game, err := s.repo.Get(ctx, uuidID)
+	if err != nil {
+		return profile.LibraryGame{}, err
+	}
+	if game.ID == "" || game.UserID != userID {
+		return profile.LibraryGame{}, ErrLibraryGameNotFound
+	}
+	return game, nil
+}
+
+func (s *Service) GetAll(ctx context.Context, userID string) ([]profile.LibraryGame, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Errorf(ctx, "GetLibrary invalid UserID: %v", err)
+
+		return nil, err
+	}
+
+	return s.repo.GetAll(ctx, uuidUserID)
+}
+
+// Update перезаписывает описание игры библиотеки пользователя game.UserID;
+// привязка к каталогу не меняется.
+func (s *Service) Update(ctx context.Context, game profile.LibraryGame) (profile.LibraryGame, error) {
+	if _, err := s.Get(ctx, game.ID, game.UserID); err != nil {
+		return profile.LibraryGame{}, err
+	}
+	id := uuid.MustParse(game.ID)
+
+	updated, err := s.repo.Update(ctx, repositorylibrary.UpdateParams{
+		ID:              id,
+		Title:           game.Title,
+		MinPlayers:      int32(game.MinPlayers),
+		MaxPlayers:      int32(game.MaxPlayers),
+		DurationMinutes: int32(game.DurationMinutes),
+		Tags:            game.Tags,
+		Link:            game.Link,
+		Notes:           game.Notes,
+	})
+	if err != nil {
+		return profile.LibraryGame{}, err
+	}
+	if updated.ID == "" {
+		return profile.LibraryGame{}, ErrLibraryGameNotFound
+	}
+	return updated, nil
+}
+
+// Delete удаляет игру из библиотеки пользователя userID; игры, уже добавленные
+// из неё в комнаты, остаются.
+func (s *Service) Delete(ctx context.Context, id, userID string) error {
+	game, err := s.Get(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, uuid.MustParse(game.ID), uuid.MustParse(game.UserID))
+}
+
+// GetRoomOwners возвращает для игр комнаты участников, у которых игра есть в библиотеке
+// (игра комнаты и игра библиотеки относятся к одной игре каталога).
+func (s *Service) GetRoomOwners(ctx context.Context, roomID string) ([]profile.GameOwners, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "GetRoomOwners invalid RoomID: %v", err)
+
+		return nil, err
+	}
+
+	owners, err := s.repo.GetRoomOwners(ctx, uuidRoomID)
+	if err != nil {
+		return nil, err
+	}
+	if owners == nil {
+		owners = []profile.GameOwners{}
+	}
+	return owners, nil
+}
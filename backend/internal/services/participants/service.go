@@ -2,11 +2,15 @@ package participants
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/profile"
 	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
 	repositoryparticipants "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/participants"
+	serviceresults "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/results"
+	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
 	"github.com/google/uuid"
 )
@@ -16,15 +20,31 @@ type ParticipantService interface {
 	GetAllParticipants(context.Context, string) ([]profile.User, []string, error)
 	Delete(context.Context, string, string) error
 	Get(context.Context, string, string) (entitiesrooms.RoomParticipant, error)
+	SetReady(context.Context, string, string, bool) (ReadyResult, error)
+	GetReadiness(context.Context, string) (entitiesrooms.Readiness, error)
+	SetPresent(context.Context, string, string, bool) (entitiesrooms.Presence, error)
+	GetPresence(context.Context, string) (entitiesrooms.Presence, error)
+	ResetPresence(context.Context, string) error
+}
+
+var ErrNotParticipant = errors.New("user is not a participant of the room")
+
+// ReadyResult - готовность комнаты после отметки участника.
+// Pick заполняется, если отметка запустила автоматический выбор игры.
+type ReadyResult struct {
+	entitiesrooms.Readiness
+	Pick *serviceresults.Spin `json:"pick,omitempty"`
 }
 
 type Service struct {
-	repo repositoryparticipants.ParticipantRepository
-	hub  hub.Hub
+	repo          repositoryparticipants.ParticipantRepository
+	roomService   servicerooms.RoomService
+	resultService serviceresults.ResultService
+	hub           hub.Hub
 }
 
-func NewService(repo repositoryparticipants.ParticipantRepository) *Service {
-	return &Service{repo: repo}
+func NewService(repo repositoryparticipants.ParticipantRepository, roomService servicerooms.RoomService, resultService serviceresults.ResultService) *Service {
+	return &Service{repo: repo, roomService: roomService, resultService: resultService}
 }
 
 func (s *Service) SetHub(h hub.Hub) {
@@ -137,3 +157,258 @@ func (s *Service) Get(ctx context.Context, roomID, userID string) (entitiesrooms
 
 	return s.repo.Get(ctx, uuidRoomID, uuidUserID)
 }
+
+// SetReady отмечает готовность участника и рассылает participant.ready.
+// Если в комнате включён auto_pick и готово достаточно участников, запускает выбор игры
+// от имени участника, отметившегося последним, и снимает готовность со всех участников.
+func (s *Service) SetReady(ctx context.Context, roomID, userID string, ready bool) (ReadyResult, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "SetReady invalid RoomID: %v", err)
+
+		return ReadyResult{}, err
+	}
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Errorf(ctx, "SetReady invalid UserID: %v", err)
+
+		return ReadyResult{}, err
+	}
+
+	participant, err := s.repo.SetReady(ctx, repositoryparticipants.SetReadyParams{
+		RoomID: uuidRoomID,
+		UserID: uuidUserID,
+		Ready:  ready,
+	})
+	if err != nil {
+		return ReadyResult{}, err
+	}
+	if participant.ID == "" {
+		return ReadyResult{}, ErrNotParticipant
+	}
+
+	room, err := s.roomService.GetByID(ctx, roomID)
+	if err != nil {
+		return ReadyResult{}, err
+	}
+
+	readiness, err := s.readiness(ctx, uuidRoomID, room)
+	if err != nil {
+		return ReadyResult{}, err
+	}
+	s.broadcastReady(roomID, userID, ready, readiness)
+
+	res := ReadyResult{Readiness: readiness}
+	if !ready || !room.AutoPick || !readiness.Reached() {
+		return res, nil
+	}
+
+	spin, err := s.resultService.Spin(ctx, roomID, userID, serviceresults.PickOptions{})
+	if err != nil {
+		// Готовность не снимается: выбор можно запустить вручную или повторной отметкой.
+		logger.Errorf(ctx, "SetReady auto pick error: %v; roomID: %v", err, roomID)
+
+		return res, nil
+	}
+	res.Pick = &spin
+
+	// Выбор уже запущен, поэтому ошибки сброса готовности только логируются.
+	if err := s.repo.ResetReady(ctx, uuidRoomID); err != nil {
+		logger.Errorf(ctx, "SetReady ResetReady error: %v; roomID: %v", err, roomID)
+
+		return res, nil
+	}
+	readiness, err = s.readiness(ctx, uuidRoomID, room)
+	if err != nil {
+		logger.Errorf(ctx, "SetReady readiness error: %v; roomID: %v", err, roomID)
+
+		return res, nil
+	}
+	res.Readiness = readiness
+	s.broadcastReady(roomID, "", false, res.Readiness)
+	return res, nil
+}
+
+func (s *Service) GetReadiness(ctx context.Context, roomID string) (entitiesrooms.Readiness, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "GetReadiness invalid RoomID: %v", err)
+
+		return entitiesrooms.Readiness{}, err
+	}
+
+	room, err := s.roomService.GetByID(ctx, roomID)
+	if err != nil {
+		return entitiesrooms.Readiness{}, err
+	}
+
+	return s.readiness(ctx, uuidRoomID, room)
+}
+
+// readiness считает готовых участников. Кворум комнаты 0 или больше числа участников означает всех участников.
+func (s *Service) readiness(ctx context.Context, uuidRoomID uuid.UUID, room entitiesrooms.Room) (entitiesrooms.Readiness, error) {
+	list, err := s.repo.GetAllParticipants(ctx, uuidRoomID)
+	if err != nil {
+		return entitiesrooms.Readiness{}, err
+	}
+
+	res := entitiesrooms.Readiness{
+		UserIDs: make([]string, 0, len(list)),
+		Total:   len(list),
+		Quorum:  len(list),
+	}
+	for _, it := range list {
+		if it.Ready {
+			res.UserIDs = append(res.UserIDs, it.User.ID)
+		}
+	}
+	res.Ready = len(res.UserIDs)
+	if room.ReadyQuorum > 0 && room.ReadyQuorum < res.Total {
+		res.Quorum = room.ReadyQuorum
+	}
+	return res, nil
+}
+
+// SetPresent отмечает, что участник пришёл на вечер (или снимает отметку), и рассылает participant.present.
+func (s *Service) SetPresent(ctx context.Context, roomID, userID string, present bool) (entitiesrooms.Presence, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "SetPresent invalid RoomID: %v", err)
+
+		return entitiesrooms.Presence{}, err
+	}
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Errorf(ctx, "SetPresent invalid UserID: %v", err)
+
+		return entitiesrooms.Presence{}, err
+	}
+
+	participant, err := s.repo.SetPresent(ctx, repositoryparticipants.SetPresentParams{
+		RoomID:  uuidRoomID,
+		UserID:  uuidUserID,
+		Present: present,
+	})
+	if err != nil {
+		return entitiesrooms.Presence{}, err
+	}
+	if participant.ID == "" {
+		return entitiesrooms.Presence{}, ErrNotParticipant
+	}
+
+	presence, err := s.presence(ctx, uuidRoomID)
+	if err != nil {
+		return entitiesrooms.Presence{}, err
+	}
+
+	if s.hub != nil {
+		s.hub.Broadcast(roomID, hub.RoomEvent{
+			Type:   hub.EventParticipantPresent,
+			RoomID: roomID,
+			Payload: map[string]any{
+				"user_id":   userID,
+				"present":   present,
+				"available": presence.Available,
+			},
+		})
+	}
+	return presence, nil
+}
+
+func (s *Service) GetPresence(ctx context.Context, roomID string) (entitiesrooms.Presence, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "GetPresence invalid RoomID: %v", err)
+
+		return entitiesrooms.Presence{}, err
+	}
+
+	return s.presence(ctx, uuidRoomID)
+}
+
+// ResetPresence снимает отметки присутствия со всех участников комнаты: они действуют
+// до конца вечера, то есть до выбора игры или открытия нового раунда. Подключённые
+// по WebSocket участники остаются доступными.
+func (s *Service) ResetPresence(ctx context.Context, roomID string) error {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "ResetPresence invalid RoomID: %v", err)
+
+		return err
+	}
+
+	if err := s.repo.ResetPresent(ctx, uuidRoomID); err != nil {
+		return err
+	}
+
+	presence, err := s.presence(ctx, uuidRoomID)
+	if err != nil {
+		return err
+	}
+
+	if s.hub != nil {
+		s.hub.Broadcast(roomID, hub.RoomEvent{
+			Type:   hub.EventParticipantPresent,
+			RoomID: roomID,
+			Payload: map[string]any{
+				"user_id":   "",
+				"present":   false,
+				"available": presence.Available,
+			},
+		})
+	}
+	return nil
+}
+
+// presence собирает отмеченных и подключённых участников комнаты. Подключения
+// пользователей, которые уже не участвуют в комнате, не учитываются.
+func (s *Service) presence(ctx context.Context, uuidRoomID uuid.UUID) (entitiesrooms.Presence, error) {
+	list, err := s.repo.GetAllParticipants(ctx, uuidRoomID)
+	if err != nil {
+		return entitiesrooms.Presence{}, err
+	}
+
+	var connected []string
+	if s.hub != nil {
+		connected = s.hub.Connected(uuidRoomID.String())
+	}
+
+	res := entitiesrooms.Presence{
+		Present:   make([]string, 0, len(list)),
+		Connected: make([]string, 0, len(connected)),
+		Available: make([]string, 0, len(list)),
+	}
+	for _, it := range list {
+		isConnected := slices.Contains(connected, it.User.ID)
+		if it.Present {
+			res.Present = append(res.Present, it.User.ID)
+		}
+		if isConnected {
+			res.Connected = append(res.Connected, it.User.ID)
+		}
+		if it.Present || isConnected {
+			res.Available = append(res.Available, it.User.ID)
+		}
+	}
+	return res, nil
+}
+
+// broadcastReady рассылает готовность комнаты. Пустой userID означает, что готовность снята со всех участников.
+func (s *Service) broadcastReady(roomID, userID string, ready bool, readiness entitiesrooms.Readiness) {
+	if s.hub == nil {
+		return
+	}
+
+	s.hub.Broadcast(roomID, hub.RoomEvent{
+		Type:   hub.EventParticipantReady,
+		RoomID: roomID,
+		Payload: map[string]any{
+			"user_id":  userID,
+			"ready":    ready,
+			"user_ids": readiness.UserIDs,
+			"count":    readiness.Ready,
+			"total":    readiness.Total,
+			"quorum":   readiness.Quorum,
+		},
+	})
+}
@@ -0,0 +1,414 @@
+package polls
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
+	repositorypolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/polls"
+	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPollNotFound = errors.New("poll not found")
+	ErrPollNotOpen  = errors.New("poll is not open")
+	ErrDeadlinePast = errors.New("poll deadline must be in the future")
+)
+
+// PresenceResetter снимает отметки присутствия участников комнаты.
+type PresenceResetter interface {
+	ResetPresence(context.Context, string) error
+}
+
+type PollService interface {
+	Open(context.Context, string, string) (entitiesrooms.Poll, error)
+	OpenRunoff(context.Context, string, string, string, []string, time.Time) (entitiesrooms.Poll, error)
+	Close(context.Context, string) (entitiesrooms.Poll, error)
+	Current(context.Context, string) (entitiesrooms.Poll, error)
+	Get(context.Context, string, string) (entitiesrooms.Poll, error)
+	GetAll(context.Context, string) ([]entitiesrooms.Poll, error)
+	MarkPicked(context.Context, string) error
+	SetDeadline(context.Context, string, time.Time) (entitiesrooms.Poll, error)
+	Expire(context.Context, string, string) (entitiesrooms.Poll, error)
+	GetPending(context.Context) ([]entitiesrooms.Poll, error)
+}
+
+// Service ведёт раунды голосования комнаты. Текущий раунд - последний раунд
+// комнаты; голоса и результаты выбора привязываются к нему.
+// Открытие раундов выполняется под mu, чтобы в комнате не появилось два открытых раунда.
+type Service struct {
+	repo        repositorypolls.PollRepository
+	roomService servicerooms.RoomService
+	presence    PresenceResetter
+	hub         hub.Hub
+	mu          sync.Mutex
+}
+
+func NewService(repo repositorypolls.PollRepository, roomService servicerooms.RoomService) *Service {
+	return &Service{repo: repo, roomService: roomService}
+}
+
+func (s *Service) SetHub(h hub.Hub) {
+	s.hub = h
+}
+
+func (s *Service) SetPresence(presence PresenceResetter) {
+	s.presence = presence
+}
+
+// Open закрывает текущий раунд комнаты, если он открыт, и открывает новый.
+// Голоса прошлого раунда остаются в нём и доступны в истории. Новый раунд начинает
+// новый вечер, поэтому отметки присутствия участников снимаются.
+func (s *Service) Open(ctx context.Context, roomID, userID string) (entitiesrooms.Poll, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "OpenPoll invalid RoomID: %v", err)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Errorf(ctx, "OpenPoll invalid UserID: %v", err)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.repo.GetCurrent(ctx, uuidRoomID)
+	if err != nil {
+		return entitiesrooms.Poll{}, err
+	}
+	if current.IsOpen() {
+		if _, err := s.close(ctx, current); err != nil {
+			return entitiesrooms.Poll{}, err
+		}
+	}
+
+	poll, err := s.create(ctx, repositorypolls.CreateParams{
+		RoomID:   uuidRoomID,
+		OpenedBy: uuidUserID,
+	})
+	if err != nil {
+		return entitiesrooms.Poll{}, err
+	}
+
+	if s.presence != nil {
+		if err := s.presence.ResetPresence(ctx, roomID); err != nil {
+			logger.Errorf(ctx, "OpenPoll ResetPresence error: %v; roomID: %v", err, roomID)
+		}
+	}
+	return poll, nil
+}
+
+// OpenRunoff закрывает раунд pollID и открывает после него дополнительный раунд
+// между играми gameIDs со сроком голосования deadline. Раунд pollID должен быть текущим.
+func (s *Service) OpenRunoff(ctx context.Context, roomID, userID, pollID string, gameIDs []string, deadline time.Time) (entitiesrooms.Poll, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "OpenRunoff invalid RoomID: %v", err)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Errorf(ctx, "OpenRunoff invalid UserID: %v", err)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	uuidPollID, err := uuid.Parse(pollID)
+	if err != nil {
+		logger.Errorf(ctx, "OpenRunoff invalid PollID: %v", err)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	candidates := make([]uuid.UUID, 0, len(gameIDs))
+	for _, gameID := range gameIDs {
+		id, err := uuid.Parse(gameID)
+		if err != nil {
+			logger.Errorf(ctx, "OpenRunoff invalid GameID: %v", err)
+
+			return entitiesrooms.Poll{}, err
+		}
+		candidates = append(candidates, id)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.repo.GetCurrent(ctx, uuidRoomID)
+	if err != nil {
+		return entitiesrooms.Poll{}, err
+	}
+	if current.ID != pollID || current.Status == entitiesrooms.PollStatusPicked {
+		return entitiesrooms.Poll{}, ErrPollNotOpen
+	}
+	if current.IsOpen() {
+		if _, err := s.close(ctx, current); err != nil {
+			return entitiesrooms.Poll{}, err
+		}
+	}
+
+	return s.create(ctx, repositorypolls.CreateParams{
+		RoomID:     uuidRoomID,
+		OpenedBy:   uuidUserID,
+		RunoffOf:   uuid.NullUUID{UUID: uuidPollID, Valid: true},
+		Candidates: candidates,
+		Deadline:   deadline,
+	})
+}
+
+// Close закрывает голосование в текущем раунде комнаты.
+func (s *Service) Close(ctx context.Context, roomID string) (entitiesrooms.Poll, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "ClosePoll invalid RoomID: %v", err)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.repo.GetCurrent(ctx, uuidRoomID)
+	if err != nil {
+		return entitiesrooms.Poll{}, err
+	}
+	if !current.IsOpen() {
+		return entitiesrooms.Poll{}, ErrPollNotOpen
+	}
+
+	return s.close(ctx, current)
+}
+
+// Current возвращает текущий раунд комнаты. Первый раунд комнаты открывается
+// от имени владельца при первом обращении.
+func (s *Service) Current(ctx context.Context, roomID string) (entitiesrooms.Poll, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "GetCurrentPoll invalid RoomID: %v", err)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	current, err := s.repo.GetCurrent(ctx, uuidRoomID)
+	if err != nil || current.ID != "" {
+		return current, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err = s.repo.GetCurrent(ctx, uuidRoomID)
+	if err != nil || current.ID != "" {
+		return current, err
+	}
+
+	room, err := s.roomService.GetByID(ctx, roomID)
+	if err != nil {
+		return entitiesrooms.Poll{}, err
+	}
+
+	ownerID, err := uuid.Parse(room.OwnerID)
+	if err != nil {
+		logger.Errorf(ctx, "GetCurrentPoll invalid OwnerID: %v", err)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	return s.create(ctx, repositorypolls.CreateParams{
+		RoomID:   uuidRoomID,
+		OpenedBy: ownerID,
+	})
+}
+
+func (s *Service) Get(ctx context.Context, roomID, pollID string) (entitiesrooms.Poll, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "GetPoll invalid RoomID: %v", err)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	uuidPollID, err := uuid.Parse(pollID)
+	if err != nil {
+		logger.Errorf(ctx, "GetPoll invalid PollID: %v", err)
+
+		return entitiesrooms.Poll{}, ErrPollNotFound
+	}
+
+	poll, err := s.repo.Get(ctx, uuidPollID, uuidRoomID)
+	if err != nil {
+		return entitiesrooms.Poll{}, err
+	}
+	if poll.ID == "" {
+		return entitiesrooms.Poll{}, ErrPollNotFound
+	}
+	return poll, nil
+}
+
+func (s *Service) GetAll(ctx context.Context, roomID string) ([]entitiesrooms.Poll, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "GetAllPolls invalid RoomID: %v", err)
+
+		return nil, err
+	}
+
+	return s.repo.GetAll(ctx, uuidRoomID)
+}
+
+// MarkPicked отмечает, что по раунду выбрана игра; голосование в раунде закрывается.
+func (s *Service) MarkPicked(ctx context.Context, pollID string) error {
+	id, err := uuid.Parse(pollID)
+	if err != nil {
+		logger.Errorf(ctx, "MarkPollPicked invalid ID: %v", err)
+
+		return err
+	}
+
+	picked, err := s.repo.MarkPicked(ctx, id)
+	if err != nil {
+		return err
+	}
+	if picked.ID != "" {
+		s.broadcastClosed(picked)
+	}
+	return nil
+}
+
+// SetDeadline задаёт срок голосования текущего открытого раунда комнаты.
+// Нулевое время снимает срок.
+func (s *Service) SetDeadline(ctx context.Context, roomID string, deadline time.Time) (entitiesrooms.Poll, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "SetPollDeadline invalid RoomID: %v", err)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	if !deadline.IsZero() && !deadline.After(time.Now()) {
+		return entitiesrooms.Poll{}, ErrDeadlinePast
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.repo.GetCurrent(ctx, uuidRoomID)
+	if err != nil {
+		return entitiesrooms.Poll{}, err
+	}
+	if !current.IsOpen() {
+		return entitiesrooms.Poll{}, ErrPollNotOpen
+	}
+
+	id, err := uuid.Parse(current.ID)
+	if err != nil {
+		logger.Errorf(ctx, "SetPollDeadline invalid ID: %v", err)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	poll, err := s.repo.SetDeadline(ctx, id, deadline)
+	if err != nil {
+		return entitiesrooms.Poll{}, err
+	}
+	if poll.ID == "" {
+		return entitiesrooms.Poll{}, ErrPollNotOpen
+	}
+
+	if s.hub != nil {
+		s.hub.Broadcast(poll.RoomID, hub.RoomEvent{
+			Type:   hub.EventPollScheduled,
+			RoomID: poll.RoomID,
+			Payload: map[string]any{
+				"id":       poll.ID,
+				"deadline": poll.Deadline,
+			},
+		})
+	}
+	return poll, nil
+}
+
+// Expire закрывает раунд, если его срок голосования наступил. Раунд, который уже закрыт
+// или срок которого перенесён или снят, не меняется: возвращается ErrPollNotOpen.
+func (s *Service) Expire(ctx context.Context, roomID, pollID string) (entitiesrooms.Poll, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	poll, err := s.Get(ctx, roomID, pollID)
+	if err != nil {
+		return entitiesrooms.Poll{}, err
+	}
+	if !poll.Expired(time.Now()) {
+		return entitiesrooms.Poll{}, ErrPollNotOpen
+	}
+
+	return s.close(ctx, poll)
+}
+
+// GetPending возвращает открытые раунды со сроком голосования во всех комнатах.
+func (s *Service) GetPending(ctx context.Context) ([]entitiesrooms.Poll, error) {
+	return s.repo.GetPending(ctx)
+}
+
+func (s *Service) create(ctx context.Context, params repositorypolls.CreateParams) (entitiesrooms.Poll, error) {
+	params.ID = uuid.New()
+	poll, err := s.repo.Create(ctx, params)
+	if err == nil && s.hub != nil {
+		s.hub.Broadcast(poll.RoomID, hub.RoomEvent{
+			Type:   hub.EventPollOpened,
+			RoomID: poll.RoomID,
+			Payload: map[string]any{
+				"id":        poll.ID,
+				"opened_by": poll.OpenedBy,
+			},
+		})
+	}
+	return poll, err
+}
+
+func (s *Service) close(ctx context.Context, poll entitiesrooms.Poll) (entitiesrooms.Poll, error) {
+	id, err := uuid.Parse(poll.ID)
+	if err != nil {
+		logger.Errorf(ctx, "ClosePoll invalid ID: %v", err)
+
+		return entitiesrooms.Poll{}, err
+	}
+
+	closed, err := s.repo.Close(ctx, id)
+	if err != nil {
+		return entitiesrooms.Poll{}, err
+	}
+	if closed.ID == "" {
+		return entitiesrooms.Poll{}, ErrPollNotOpen
+	}
+
+	s.broadcastClosed(closed)
+	return closed, nil
+}
+
+func (s *Service) broadcastClosed(poll entitiesrooms.Poll) {
+	if s.hub == nil {
+		return
+	}
+
+	s.hub.Broadcast(poll.RoomID, hub.RoomEvent{
+		Type:   hub.EventPollClosed,
+		RoomID: poll.RoomID,
+		Payload: map[string]any{
+			"id":     poll.ID,
+			"status": poll.Status,
+		},
+	})
+}
@@ -0,0 +1,122 @@
+package results
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	mathrand "math/rand/v2"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+var (
+	ErrResultNotFound = errors.New("result not found")
+	ErrNotVerifiable  = errors.New("result has no revealed seed")
+)
+
+// Verification - итог проверки розыгрыша: совпадает ли обязательство с раскрытым зерном
+// и сохранённым снимком и приводит ли повтор розыгрыша с этим зерном к той же игре.
+type Verification struct {
+	ResultID         string                     `json:"result_id"`
+	GameID           string                     `json:"game_id"`
+	RecomputedGameID string                     `json:"recomputed_game_id"`
+	Strategy         string                     `json:"strategy"`
+	Seed             string                     `json:"seed"`
+	Commitment       string                     `json:"commitment"`
+	CommitmentValid  bool                       `json:"commitment_valid"`
+	Valid            bool                       `json:"valid"`
+	Position         int                        `json:"position"`
+	Snapshot         entitiesrooms.DrawSnapshot `json:"snapshot"`
+	Rounds           []RunoffRound              `json:"rounds,omitempty"`
+}
+
+// newSeed генерирует криптографически случайное зерно розыгрыша.
+func newSeed() ([32]byte, error) {
+	var seed [32]byte
+	_, err := rand.Read(seed[:])
+	return seed, err
+}
+
+// commit возвращает обязательство розыгрыша - hex от SHA-256 зерна, за которым следует снимок в JSON.
+// Обязательство связывает зерно со снимком, поэтому после публикации нельзя подменить
+// ни кандидатов с весами, ни зерно.
+func commit(seed [32]byte, snapshot entitiesrooms.DrawSnapshot) (string, error) {
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write(seed[:])
+	h.Write(raw)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func parseSeed(s string) ([32]byte, error) {
+	var seed [32]byte
+	raw, err := hex.DecodeString(s)
+	if err != nil {
+		return seed, err
+	}
+	if len(raw) != len(seed) {
+		return seed, ErrNotVerifiable
+	}
+	copy(seed[:], raw)
+	return seed, nil
+}
+
+// replay проводит розыгрыш count игр без повторений по снимку детерминированно:
+// одно и то же зерно и один и тот же снимок всегда дают одну и ту же подборку.
+// Первая игра подборки совпадает с результатом розыгрыша одной игры с тем же зерном.
+func replay(strategy string, seed [32]byte, snapshot entitiesrooms.DrawSnapshot, count int) ([]Draw, error) {
+	rng := mathrand.New(mathrand.NewChaCha8(seed))
+
+	candidates := make([]entitiesrooms.Candidate, 0, len(snapshot.Candidates))
+	weights := make([]float64, 0, len(snapshot.Candidates))
+	for _, c := range snapshot.Candidates {
+		candidates = append(candidates, entitiesrooms.Candidate{GameID: c.GameID})
+		weights = append(weights, c.Weight)
+	}
+
+	var ballots []entitiesrooms.Ballot
+	if strategy == entitiesrooms.PickStrategyRanked {
+		ballots = make([]entitiesrooms.Ballot, 0, len(snapshot.Ballots))
+		for _, rankings := range snapshot.Ballots {
+			ballots = append(ballots, entitiesrooms.Ballot{Rankings: rankings})
+		}
+	}
+
+	lineup := make([]Draw, 0, count)
+	for position := 0; position < count; position++ {
+		var (
+			gameID string
+			rounds []RunoffRound
+			err    error
+		)
+		if strategy == entitiesrooms.PickStrategyRanked {
+			gameID, rounds, err = instantRunoff(rng, candidates, ballots)
+		} else {
+			gameID, err = draw(rng, candidates, weights)
+		}
+		if errors.Is(err, ErrNoCandidates) && position > 0 {
+			return nil, fmt.Errorf("%w: only %d of %d", ErrNotEnoughCandidates, position, count)
+		}
+		if err != nil {
+			return nil, err
+		}
+		lineup = append(lineup, Draw{Position: position, GameID: gameID, Rounds: rounds})
+
+		// Выпавшая игра больше не участвует в розыгрыше следующих мест подборки.
+		for i, c := range candidates {
+			if c.GameID == gameID {
+				candidates = append(candidates[:i], candidates[i+1:]...)
+				weights = append(weights[:i], weights[i+1:]...)
+				break
+			}
+		}
+	}
+	return lineup, nil
+}
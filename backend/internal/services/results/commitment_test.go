@@ -0,0 +1,199 @@
+package results
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+func snapshotOf(weights map[string]float64, ballots ...[]string) entitiesrooms.DrawSnapshot {
+	snapshot := entitiesrooms.DrawSnapshot{Ballots: ballots}
+	// Кандидаты сортируются, чтобы снимок не зависел от порядка обхода карты.
+	for _, gameID := range slices.Sorted(maps.Keys(weights)) {
+		snapshot.Candidates = append(snapshot.Candidates, entitiesrooms.WeightedCandidate{GameID: gameID, Weight: weights[gameID]})
+	}
+	return snapshot
+}
+
+func gamesOf(lineup []Draw) []string {
+	res := make([]string, 0, len(lineup))
+	for _, d := range lineup {
+		res = append(res, d.GameID)
+	}
+	return res
+}
+
+func TestReplay(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		snapshot entitiesrooms.DrawSnapshot
+		count    int
+		// allowed - игры, которые могут попасть в подборку; nil - любые из снимка.
+		allowed []string
+		wantErr error
+	}{
+		{
+			name:     "weighted single game",
+			strategy: entitiesrooms.PickStrategyWeighted,
+			snapshot: snapshotOf(map[string]float64{"a": 1, "b": 2, "c": 3}),
+			count:    1,
+		},
+		{
+			name:     "weighted lineup without repeats",
+			strategy: entitiesrooms.PickStrategyWeighted,
+			snapshot: snapshotOf(map[string]float64{"a": 1, "b": 2, "c": 3}),
+			count:    3,
+		},
+		{
+			name:     "zero weight is never drawn",
+			strategy: entitiesrooms.PickStrategyWeighted,
+			snapshot: snapshotOf(map[string]float64{"a": 0, "b": 1, "c": 1}),
+			count:    2,
+			allowed:  []string{"b", "c"},
+		},
+		{
+			name:     "ranked lineup follows ballots",
+			strategy: entitiesrooms.PickStrategyRanked,
+			snapshot: snapshotOf(map[string]float64{"a": 1, "b": 1, "c": 1}, []string{"a", "b"}, []string{"a", "b"}, []string{"b"}),
+			count:    2,
+			allowed:  []string{"a", "b"},
+		},
+		{
+			name:     "empty snapshot",
+			strategy: entitiesrooms.PickStrategyWeighted,
+			snapshot: entitiesrooms.DrawSnapshot{},
+			count:    1,
+			wantErr:  ErrNoCandidates,
+		},
+		{
+			name:     "more games than candidates",
+			strategy: entitiesrooms.PickStrategyWeighted,
+			snapshot: snapshotOf(map[string]float64{"a": 1, "b": 1}),
+			count:    3,
+			wantErr:  ErrNotEnoughCandidates,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seed := [32]byte{1, 2, 3}
+			lineup, err := replay(tt.strategy, seed, tt.snapshot, tt.count)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			games := gamesOf(lineup)
+			if len(games) != tt.count {
+				t.Fatalf("lineup = %v, want %d games", games, tt.count)
+			}
+			for i, d := range lineup {
+				if d.Position != i {
+					t.Errorf("position of %q = %d, want %d", d.GameID, d.Position, i)
+				}
+				if slices.Contains(games[:i], d.GameID) {
+					t.Errorf("game %q is repeated in %v", d.GameID, games)
+				}
+				if tt.allowed != nil && !slices.Contains(tt.allowed, d.GameID) {
+					t.Errorf("game %q is not one of %v", d.GameID, tt.allowed)
+				}
+			}
+
+			again, err := replay(tt.strategy, seed, tt.snapshot, tt.count)
+			if err != nil {
+				t.Fatalf("unexpected error on replay: %v", err)
+			}
+			if !slices.Equal(gamesOf(again), games) {
+				t.Errorf("replay with the same seed = %v, want %v", gamesOf(again), games)
+			}
+
+			single, err := replay(tt.strategy, seed, tt.snapshot, 1)
+			if err != nil {
+				t.Fatalf("unexpected error on single replay: %v", err)
+			}
+			if single[0].GameID != games[0] {
+				t.Errorf("single draw = %q, want the first game of the lineup %q", single[0].GameID, games[0])
+			}
+		})
+	}
+}
+
+func TestCommit(t *testing.T) {
+	snapshot := snapshotOf(map[string]float64{"a": 1, "b": 2})
+	base, err := commit([32]byte{1}, snapshot)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(base) != 64 {
+		t.Fatalf("commitment length = %d, want 64 hex chars", len(base))
+	}
+
+	tests := []struct {
+		name     string
+		seed     [32]byte
+		snapshot entitiesrooms.DrawSnapshot
+		same     bool
+	}{
+		{name: "same seed and snapshot", seed: [32]byte{1}, snapshot: snapshot, same: true},
+		{name: "other seed", seed: [32]byte{2}, snapshot: snapshot},
+		{name: "other weights", seed: [32]byte{1}, snapshot: snapshotOf(map[string]float64{"a": 1, "b": 3})},
+		{name: "other ballots", seed: [32]byte{1}, snapshot: snapshotOf(map[string]float64{"a": 1, "b": 2}, []string{"a"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := commit(tt.seed, tt.snapshot)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (got == base) != tt.same {
+				t.Errorf("commitment = %s, base = %s, want same = %v", got, base, tt.same)
+			}
+		})
+	}
+}
+
+func TestParseSeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		seed    string
+		want    [32]byte
+		wantErr bool
+	}{
+		{
+			name: "valid seed",
+			seed: "0102000000000000000000000000000000000000000000000000000000000000",
+			want: [32]byte{1, 2},
+		},
+		{name: "not hex", seed: "zz", wantErr: true},
+		{name: "too short", seed: "0102", wantErr: true},
+		{name: "empty", seed: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSeed(tt.seed)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseSeed(%q) = %x, want error", tt.seed, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseSeed(%q) = %x, want %x", tt.seed, got, tt.want)
+			}
+		})
+	}
+}
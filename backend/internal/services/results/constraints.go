@@ -0,0 +1,112 @@
+package results
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+// Ограничения, по которым игра может не подойти для розыгрыша.
+const (
+	ExclusionPlayers     = "players"
+	ExclusionDuration    = "duration"
+	ExclusionTags        = "tags"
+	ExclusionExcludeTags = "exclude_tags"
+	ExclusionAvailable   = "available"
+)
+
+// Presence - присутствие участников комнаты. По нему выбор идёт только из игр
+// присутствующих, а после выбора отметки присутствия снимаются.
+type Presence interface {
+	GetPresence(context.Context, string) (entitiesrooms.Presence, error)
+	ResetPresence(context.Context, string) error
+}
+
+// Constraints - ограничения на игры розыгрыша. Players - сколько человек будет играть,
+// MaxMinutes - максимальная длительность партии, Tags - метки, которые должны быть у игры все,
+// ExcludeTags - метки, ни одной из которых у игры быть не должно. Нулевые значения не ограничивают,
+// а неуказанные у игры состав и длительность считаются подходящими.
+// AvailableOnly оставляет только игры, которые приносит кто-то из участников Available;
+// игры, для которых не указано, кто их приносит, при этом не участвуют. В комнате
+// с available_only ограничение действует всегда, а Available заполняется при сборе розыгрыша.
+type Constraints struct {
+	Players       int
+	MaxMinutes    int
+	Tags          []string
+	ExcludeTags   []string
+	AvailableOnly bool
+	Available     []string
+}
+
+func (c Constraints) IsZero() bool {
+	return c.Players == 0 && c.MaxMinutes == 0 && len(c.Tags) == 0 && len(c.ExcludeTags) == 0 && !c.AvailableOnly
+}
+
+// exclusion возвращает первое ограничение, которому игра не удовлетворяет, или пустую строку.
+func (c Constraints) exclusion(game entitiesrooms.Candidate) string {
+	switch {
+	case c.Players > 0 && game.MinPlayers > 0 && c.Players < game.MinPlayers,
+		c.Players > 0 && game.MaxPlayers > 0 && c.Players > game.MaxPlayers:
+		return ExclusionPlayers
+	case c.MaxMinutes > 0 && game.DurationMinutes > c.MaxMinutes:
+		return ExclusionDuration
+	}
+	for _, tag := range c.Tags {
+		if !slices.Contains(game.Tags, tag) {
+			return ExclusionTags
+		}
+	}
+	for _, tag := range c.ExcludeTags {
+		if slices.Contains(game.Tags, tag) {
+			return ExclusionExcludeTags
+		}
+	}
+	if c.AvailableOnly && (game.BroughtBy == "" || !slices.Contains(c.Available, game.BroughtBy)) {
+		return ExclusionAvailable
+	}
+	return ""
+}
+
+// ConstraintError - ни одна игра не подошла под ограничения розыгрыша.
+// Excluded - сколько игр отсеяло каждое ограничение (игра считается по первому
+// ограничению, которому не удовлетворяет).
+type ConstraintError struct {
+	Excluded map[string]int
+}
+
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("no games match the constraints: %v", e.Excluded)
+}
+
+func (e *ConstraintError) Unwrap() error {
+	return ErrNoCandidates
+}
+
+// unmatched возвращает ConstraintError, если без ограничений в розыгрыше остались бы игры,
+// а с ними - ни одной. Иначе возвращается nil.
+func unmatched(d draft) error {
+	if len(d.candidates) > 0 || d.opts.Constraints.IsZero() {
+		return nil
+	}
+
+	excluded := make(map[string]int)
+	for _, c := range d.games {
+		if reason := exclusion(c, d.poll, d.opts); isConstraint(reason) {
+			excluded[reason]++
+		}
+	}
+	if len(excluded) == 0 {
+		return nil
+	}
+	return &ConstraintError{Excluded: excluded}
+}
+
+func isConstraint(reason string) bool {
+	switch reason {
+	case ExclusionPlayers, ExclusionDuration, ExclusionTags, ExclusionExcludeTags, ExclusionAvailable:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package results
+
+import (
+	"testing"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+func TestConstraintsExclusion(t *testing.T) {
+	azul := entitiesrooms.Candidate{
+		GameID:          "azul",
+		MinPlayers:      2,
+		MaxPlayers:      4,
+		DurationMinutes: 45,
+		Tags:            []string{"abstract", "family"},
+		BroughtBy:       "u1",
+	}
+	unknown := entitiesrooms.Candidate{GameID: "unknown"}
+
+	tests := []struct {
+		name        string
+		constraints Constraints
+		game        entitiesrooms.Candidate
+		want        string
+	}{
+		{name: "no constraints", game: azul},
+		{name: "players within range", constraints: Constraints{Players: 3}, game: azul},
+		{name: "too few players", constraints: Constraints{Players: 1}, game: azul, want: ExclusionPlayers},
+		{name: "too many players", constraints: Constraints{Players: 5}, game: azul, want: ExclusionPlayers},
+		{name: "unknown player count fits", constraints: Constraints{Players: 9}, game: unknown},
+		{name: "duration fits", constraints: Constraints{MaxMinutes: 45}, game: azul},
+		{name: "too long", constraints: Constraints{MaxMinutes: 30}, game: azul, want: ExclusionDuration},
+		{name: "unknown duration fits", constraints: Constraints{MaxMinutes: 30}, game: unknown},
+		{name: "has all tags", constraints: Constraints{Tags: []string{"family", "abstract"}}, game: azul},
+		{name: "missing a tag", constraints: Constraints{Tags: []string{"family", "party"}}, game: azul, want: ExclusionTags},
+		{name: "no excluded tags", constraints: Constraints{ExcludeTags: []string{"party"}}, game: azul},
+		{name: "has an excluded tag", constraints: Constraints{ExcludeTags: []string{"party", "family"}}, game: azul, want: ExclusionExcludeTags},
+		{name: "brought by an available participant", constraints: Constraints{AvailableOnly: true, Available: []string{"u2", "u1"}}, game: azul},
+		{name: "brought by an absent participant", constraints: Constraints{AvailableOnly: true, Available: []string{"u2"}}, game: azul, want: ExclusionAvailable},
+		{name: "nobody brings the game", constraints: Constraints{AvailableOnly: true, Available: []string{"u1"}}, game: unknown, want: ExclusionAvailable},
+		{
+			name:        "first failed constraint wins",
+			constraints: Constraints{Players: 6, MaxMinutes: 30, Tags: []string{"party"}},
+			game:        azul,
+			want:        ExclusionPlayers,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.constraints.exclusion(tt.game); got != tt.want {
+				t.Errorf("exclusion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
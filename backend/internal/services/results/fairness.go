@@ -0,0 +1,77 @@
+package results
+
+import (
+	"context"
+	"math"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	repositoryresults "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/results"
+	"github.com/google/uuid"
+)
+
+// fairnessWindow - сколько последних результатов комнаты учитывает режим справедливости.
+const fairnessWindow = 10
+
+// Границы множителя голосов участника в режиме справедливости.
+const (
+	fairnessMin = 0.5
+	fairnessMax = 2.0
+)
+
+// withFairness пересчитывает очки кандидатов в режиме справедливости. Участник получает
+// множитель (1 + среднее число побед) / (1 + его число побед) в пределах [fairnessMin, fairnessMax]:
+// голоса тех, чьи игры давно не выигрывали, весят больше, а недавних победителей - меньше.
+// Победа - результат из последних fairnessWindow, за игру которого участник голосовал в своём раунде.
+func (s *Service) withFairness(ctx context.Context, roomID, pollID uuid.UUID, candidates []entitiesrooms.Candidate) ([]entitiesrooms.Candidate, []entitiesrooms.Fairness, error) {
+	fairness, err := s.repo.GetWins(ctx, repositoryresults.GetWinsParams{
+		RoomID:        roomID,
+		PollID:        pollID,
+		WindowResults: fairnessWindow,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(fairness) == 0 {
+		return candidates, nil, nil
+	}
+
+	approvals, err := s.repo.GetApprovals(ctx, pollID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	multipliers := setMultipliers(fairness)
+	scores := make(map[string]float64, len(candidates))
+	for _, a := range approvals {
+		scores[a.GameID] += float64(a.Points) * multipliers[a.UserID]
+	}
+
+	res := make([]entitiesrooms.Candidate, len(candidates))
+	for i, c := range candidates {
+		c.Score = round(scores[c.GameID])
+		res[i] = c
+	}
+	return res, fairness, nil
+}
+
+// setMultipliers заполняет множители участников по числу их побед и возвращает их по ID участника.
+func setMultipliers(fairness []entitiesrooms.Fairness) map[string]float64 {
+	var total int
+	for _, f := range fairness {
+		total += f.Wins
+	}
+	average := float64(total) / float64(len(fairness))
+
+	multipliers := make(map[string]float64, len(fairness))
+	for i, f := range fairness {
+		multiplier := min(max((1+average)/float64(1+f.Wins), fairnessMin), fairnessMax)
+		fairness[i].Multiplier = round(multiplier)
+		multipliers[f.UserID] = fairness[i].Multiplier
+	}
+	return multipliers
+}
+
+// round округляет до сотых, чтобы равные по смыслу очки совпадали при сравнении.
+func round(x float64) float64 {
+	return math.Round(x*100) / 100
+}
@@ -0,0 +1,61 @@
+package results
+
+import (
+	"maps"
+	"testing"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+func TestSetMultipliers(t *testing.T) {
+	tests := []struct {
+		name string
+		wins map[string]int
+		want map[string]float64
+	}{
+		{
+			name: "no wins",
+			wins: map[string]int{"u1": 0, "u2": 0},
+			want: map[string]float64{"u1": 1, "u2": 1},
+		},
+		{
+			name: "equal wins",
+			wins: map[string]int{"u1": 3, "u2": 3},
+			want: map[string]float64{"u1": 1, "u2": 1},
+		},
+		{
+			name: "recent winner weighs less",
+			wins: map[string]int{"u1": 2, "u2": 0},
+			want: map[string]float64{"u1": 0.67, "u2": 2},
+		},
+		{
+			name: "clamped to the bounds",
+			wins: map[string]int{"u1": 9, "u2": 0, "u3": 0},
+			want: map[string]float64{"u1": fairnessMin, "u2": fairnessMax, "u3": fairnessMax},
+		},
+		{
+			name: "within the bounds",
+			wins: map[string]int{"u1": 1, "u2": 2, "u3": 3},
+			want: map[string]float64{"u1": 1.5, "u2": 1, "u3": 0.75},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fairness := make([]entitiesrooms.Fairness, 0, len(tt.wins))
+			for userID, wins := range tt.wins {
+				fairness = append(fairness, entitiesrooms.Fairness{UserID: userID, Wins: wins})
+			}
+
+			got := setMultipliers(fairness)
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("multipliers = %v, want %v", got, tt.want)
+			}
+			for _, f := range fairness {
+				if f.Multiplier != tt.want[f.UserID] {
+					t.Errorf("multiplier of %s = %v, want %v", f.UserID, f.Multiplier, tt.want[f.UserID])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package results
+
+import (
+	"errors"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+// MaxLineupSize - наибольшее число игр, которое можно выбрать за один розыгрыш.
+const MaxLineupSize = 10
+
+var (
+	ErrInvalidCount        = errors.New("invalid number of games to pick")
+	ErrNotEnoughCandidates = errors.New("not enough games to pick from")
+)
+
+// Draw - игра, выпавшая на месте Position подборки. ID - сохранённый результат,
+// Rounds заполняется только для ранжированного голосования.
+type Draw struct {
+	ID       string        `json:"id,omitempty"`
+	Position int           `json:"position"`
+	GameID   string        `json:"game_id"`
+	Rounds   []RunoffRound `json:"rounds,omitempty"`
+}
+
+// groupLineups собирает результаты в подборки по BatchID, сохраняя порядок результатов.
+func groupLineups(results []entitiesrooms.Result) []entitiesrooms.Lineup {
+	res := make([]entitiesrooms.Lineup, 0, len(results))
+	index := make(map[string]int, len(results))
+	for _, result := range results {
+		i, ok := index[result.BatchID]
+		if !ok {
+			i = len(res)
+			index[result.BatchID] = i
+			res = append(res, entitiesrooms.Lineup{
+				BatchID:   result.BatchID,
+				PollID:    result.PollID,
+				RerollOf:  result.RerollOf,
+				ChosenBy:  result.ChosenBy,
+				Strategy:  result.Strategy,
+				CreatedAt: result.CreatedAt,
+			})
+		}
+		res[i].Games = append(res[i].Games, entitiesrooms.LineupGame{
+			ResultID: result.ID,
+			Position: result.Position,
+			GameID:   result.GameID,
+			Title:    result.GameTitle,
+			Archived: result.GameArchived,
+			Reroll:   result.Reroll,
+		})
+	}
+	return res
+}
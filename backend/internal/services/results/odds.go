@@ -0,0 +1,152 @@
+package results
+
+import (
+	"context"
+	"encoding/binary"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/google/uuid"
+)
+
+// oddsSamples - число повторов розыгрыша, по которым оцениваются шансы
+// при ранжированном голосовании.
+const oddsSamples = 1000
+
+// GameOdds - шансы одной игры комнаты. Score - очки голосов с учётом множителей справедливости,
+// Weight - вес игры в стратегии (для ранжированного голосования не заполняется),
+// Excluded - причина, по которой игра не участвует в розыгрыше.
+type GameOdds struct {
+	GameID      string  `json:"game_id"`
+	Votes       int64   `json:"votes"`
+	Score       float64 `json:"score"`
+	Vetoes      int64   `json:"vetoes"`
+	Weight      float64 `json:"weight"`
+	Probability float64 `json:"probability"`
+	Excluded    string  `json:"excluded,omitempty"`
+}
+
+// Odds - вероятности выбора игр комнаты, если розыгрыш пройдёт прямо сейчас.
+// Для ранжированного голосования вероятности оцениваются повтором розыгрыша
+// с oddsSamples фиксированными зёрнами, и Estimated равен true.
+// Fairness - множители голосов участников, если в комнате включён режим справедливости.
+type Odds struct {
+	Strategy  string                   `json:"strategy"`
+	PollID    string                   `json:"poll_id"`
+	Estimated bool                     `json:"estimated"`
+	Games     []GameOdds               `json:"games"`
+	Fairness  []entitiesrooms.Fairness `json:"fairness,omitempty"`
+}
+
+// Odds рассчитывает шансы игр текущего раунда комнаты при стратегии strategy
+// (пустая - стратегия комнаты) и ограничениях constraints.
+// Кандидаты и веса собираются так же, как для PickResult.
+func (s *Service) Odds(ctx context.Context, roomID, strategy string, constraints Constraints) (Odds, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "GetOdds invalid RoomID: %v", err)
+
+		return Odds{}, err
+	}
+
+	opts := PickOptions{Strategy: strategy, Constraints: constraints}
+	draft, err := s.prepare(ctx, uuidRoomID, opts)
+	if err != nil {
+		return Odds{}, err
+	}
+
+	var probabilities map[string]float64
+	if draft.strategy == entitiesrooms.PickStrategyRanked {
+		probabilities = estimate(draft)
+	} else {
+		probabilities = share(draft.snapshot)
+	}
+
+	weights := make(map[string]float64, len(draft.snapshot.Candidates))
+	for _, c := range draft.snapshot.Candidates {
+		weights[c.GameID] = c.Weight
+	}
+
+	games := make([]GameOdds, 0, len(draft.games))
+	for _, c := range draft.games {
+		games = append(games, GameOdds{
+			GameID:      c.GameID,
+			Votes:       c.Votes,
+			Score:       c.Score,
+			Vetoes:      c.Vetoes,
+			Weight:      weights[c.GameID],
+			Probability: probabilities[c.GameID],
+			Excluded:    exclusion(c, draft.poll, draft.opts),
+		})
+	}
+
+	return Odds{
+		Strategy:  draft.strategy,
+		PollID:    draft.poll.ID,
+		Estimated: draft.strategy == entitiesrooms.PickStrategyRanked,
+		Games:     games,
+		Fairness:  draft.fairness,
+	}, nil
+}
+
+// PublishOdds пересчитывает шансы игр комнаты и рассылает их событием odds.updated.
+// Вызывается после изменения голосов; ошибки только логируются, чтобы не отменять сам голос.
+func (s *Service) PublishOdds(ctx context.Context, roomID string) {
+	if s.hub == nil {
+		return
+	}
+
+	odds, err := s.Odds(ctx, roomID, "", Constraints{})
+	if err != nil {
+		logger.Errorf(ctx, "PublishOdds Odds error: %v; roomID: %v", err, roomID)
+
+		return
+	}
+
+	s.hub.Broadcast(roomID, hub.RoomEvent{
+		Type:   hub.EventOddsUpdated,
+		RoomID: roomID,
+		Payload: map[string]any{
+			"strategy":  odds.Strategy,
+			"poll_id":   odds.PollID,
+			"estimated": odds.Estimated,
+			"games":     odds.Games,
+			"fairness":  odds.Fairness,
+		},
+	})
+}
+
+// share возвращает вероятность каждой игры снимка - её вес, делённый на сумму весов.
+func share(snapshot entitiesrooms.DrawSnapshot) map[string]float64 {
+	var total float64
+	for _, c := range snapshot.Candidates {
+		total += c.Weight
+	}
+
+	res := make(map[string]float64, len(snapshot.Candidates))
+	if total <= 0 {
+		return res
+	}
+	for _, c := range snapshot.Candidates {
+		res[c.GameID] = c.Weight / total
+	}
+	return res
+}
+
+// estimate оценивает вероятности игр повтором розыгрыша с oddsSamples фиксированными зёрнами,
+// поэтому при тех же голосах оценка не меняется. Если бюллетеней нет, шансы у всех игр нулевые.
+func estimate(draft draft) map[string]float64 {
+	res := make(map[string]float64, len(draft.snapshot.Candidates))
+	for i := 0; i < oddsSamples; i++ {
+		var seed [32]byte
+		binary.LittleEndian.PutUint64(seed[:], uint64(i))
+
+		lineup, err := replay(draft.strategy, seed, draft.snapshot, 1)
+		if err != nil {
+			return map[string]float64{}
+		}
+		res[lineup[0].GameID] += 1.0 / oddsSamples
+	}
+	return res
+}
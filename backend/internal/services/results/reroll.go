@@ -0,0 +1,191 @@
+package results
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
+	repositoryresults "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/results"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAlreadyRerolled    = errors.New("result is already rerolled")
+	ErrRerollLimitReached = errors.New("reroll limit reached")
+)
+
+// rerollWindow - срок, за который считается лимит перевыборов комнаты: один игровой вечер.
+const rerollWindow = 24 * time.Hour
+
+// Reroll отменяет результат resultID и выбирает вместо него другую игру по голосам
+// того же раунда с той же стратегией и ограничениями. Из розыгрыша исключаются игры подборки результата
+// и все игры, отменённые раньше в цепочке перевыборов. Новый результат ссылается на
+// отменённый, а в отменённом сохраняется, кто и почему его перевыбрал.
+// За rerollWindow в комнате можно перевыбрать не больше reroll_limit результатов.
+func (s *Service) Reroll(ctx context.Context, roomID, resultID, userID, reason string) (Spin, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "RerollResult invalid RoomID: %v", err)
+
+		return Spin{}, err
+	}
+
+	uuidResultID, err := uuid.Parse(resultID)
+	if err != nil {
+		logger.Errorf(ctx, "RerollResult invalid ResultID: %v", err)
+
+		return Spin{}, ErrResultNotFound
+	}
+
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Errorf(ctx, "RerollResult invalid UserID: %v", err)
+
+		return Spin{}, err
+	}
+
+	result, err := s.repo.GetResult(ctx, uuidResultID, uuidRoomID)
+	if err != nil {
+		return Spin{}, err
+	}
+	if result.ID == "" {
+		return Spin{}, ErrResultNotFound
+	}
+	if result.Reroll != nil {
+		return Spin{}, ErrAlreadyRerolled
+	}
+
+	room, err := s.roomService.GetByID(ctx, roomID)
+	if err != nil {
+		return Spin{}, err
+	}
+
+	exclude, err := s.rerolledGames(ctx, uuidRoomID, result)
+	if err != nil {
+		return Spin{}, err
+	}
+
+	// Результат отменяется до выбора замены: отмена и проверка лимита идут одной транзакцией,
+	// так что параллельный перевыбор того же результата или сверх лимита выбор не запустит.
+	rerolled, rerolls, err := s.repo.MarkRerolled(ctx, repositoryresults.MarkRerolledParams{
+		ID:          uuidResultID,
+		RoomID:      uuidRoomID,
+		RerolledBy:  uuidUserID,
+		Reason:      reason,
+		Limit:       room.RerollLimit,
+		WindowHours: int32(rerollWindow.Hours()),
+	})
+	if err != nil {
+		return Spin{}, err
+	}
+	if rerolls >= room.RerollLimit {
+		return Spin{}, ErrRerollLimitReached
+	}
+	if rerolled.ID == "" {
+		return Spin{}, ErrAlreadyRerolled
+	}
+
+	opts := storedOptions(result.Options)
+	opts.Strategy = result.Strategy
+	opts.PollID = result.PollID
+	opts.Exclude = exclude
+	opts.RerollOf = result.ID
+
+	spin, err := s.Spin(ctx, roomID, userID, opts)
+	if err != nil {
+		// Замену выбрать не удалось: результат остаётся в силе и не расходует лимит.
+		if err := s.repo.UnmarkRerolled(ctx, uuidResultID); err != nil {
+			logger.Errorf(ctx, "RerollResult unmark error: %v; resultID: %v", err, resultID)
+		}
+
+		return Spin{}, err
+	}
+
+	if s.hub != nil {
+		s.hub.Broadcast(roomID, hub.RoomEvent{
+			Type:   hub.EventPickRerolled,
+			RoomID: roomID,
+			Payload: map[string]any{
+				"result_id":   result.ID,
+				"game_id":     result.GameID,
+				"rerolled_by": userID,
+				"reason":      reason,
+				"id":          spin.ID,
+				"batch_id":    spin.BatchID,
+			},
+		})
+	}
+	return spin, nil
+}
+
+// stored возвращает ограничения розыгрыша, которые сохраняются с результатом.
+func (o PickOptions) stored() entitiesrooms.PickOptions {
+	return entitiesrooms.PickOptions{
+		IgnoreCooldown: o.IgnoreCooldown,
+		Players:        o.Constraints.Players,
+		MaxMinutes:     o.Constraints.MaxMinutes,
+		Tags:           o.Constraints.Tags,
+		ExcludeTags:    o.Constraints.ExcludeTags,
+		AvailableOnly:  o.Constraints.AvailableOnly,
+		Available:      o.Constraints.Available,
+	}
+}
+
+// storedOptions восстанавливает параметры розыгрыша из ограничений сохранённого результата.
+func storedOptions(o entitiesrooms.PickOptions) PickOptions {
+	return PickOptions{
+		IgnoreCooldown: o.IgnoreCooldown,
+		Constraints: Constraints{
+			Players:       o.Players,
+			MaxMinutes:    o.MaxMinutes,
+			Tags:          o.Tags,
+			ExcludeTags:   o.ExcludeTags,
+			AvailableOnly: o.AvailableOnly,
+			Available:     o.Available,
+		},
+	}
+}
+
+// rerolledGames возвращает игры, которые нельзя выбрать при перевыборе result:
+// игры его подборки и подборок всех результатов, которые он заменил.
+func (s *Service) rerolledGames(ctx context.Context, roomID uuid.UUID, result entitiesrooms.Result) ([]string, error) {
+	var res []string
+	for {
+		batchID, err := uuid.Parse(result.BatchID)
+		if err != nil {
+			logger.Errorf(ctx, "RerollResult invalid BatchID: %v", err)
+
+			return nil, err
+		}
+
+		batch, err := s.repo.GetBatch(ctx, roomID, batchID)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range batch {
+			res = append(res, r.GameID)
+		}
+
+		if result.RerollOf == "" {
+			return res, nil
+		}
+
+		prevID, err := uuid.Parse(result.RerollOf)
+		if err != nil {
+			logger.Errorf(ctx, "RerollResult invalid RerollOf: %v", err)
+
+			return nil, err
+		}
+
+		result, err = s.repo.GetResult(ctx, prevID, roomID)
+		if err != nil {
+			return nil, err
+		}
+		if result.ID == "" {
+			return res, nil
+		}
+	}
+}
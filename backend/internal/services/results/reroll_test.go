@@ -0,0 +1,157 @@
+package results
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	repositoryresults "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/results"
+	"github.com/google/uuid"
+)
+
+func (r *fakeRepo) GetResult(_ context.Context, id, _ uuid.UUID) (entitiesrooms.Result, error) {
+	return r.results[id.String()], nil
+}
+
+func (r *fakeRepo) GetBatch(_ context.Context, _, batchID uuid.UUID) ([]entitiesrooms.Result, error) {
+	var res []entitiesrooms.Result
+	for _, result := range r.results {
+		if result.BatchID == batchID.String() {
+			res = append(res, result)
+		}
+	}
+	return res, nil
+}
+
+// MarkRerolled, как и запрос репозитория, не отменяет результат, если лимит исчерпан.
+func (r *fakeRepo) MarkRerolled(_ context.Context, params repositoryresults.MarkRerolledParams) (entitiesrooms.Result, int, error) {
+	rerolls := r.rerolls
+	if rerolls >= params.Limit {
+		return entitiesrooms.Result{}, rerolls, nil
+	}
+
+	result := r.results[params.ID.String()]
+	result.Reroll = &entitiesrooms.Reroll{By: params.RerolledBy.String(), Reason: params.Reason, At: time.Now()}
+	r.results[params.ID.String()] = result
+	r.rerolls++
+	return result, rerolls, nil
+}
+
+func (r *fakeRepo) UnmarkRerolled(_ context.Context, id uuid.UUID) error {
+	r.unmarked = append(r.unmarked, id)
+	return nil
+}
+
+func TestReroll(t *testing.T) {
+	owner, guest := uuid.New().String(), uuid.New().String()
+	picked := entitiesrooms.Candidate{GameID: uuid.New().String(), Votes: 3, BroughtBy: owner}
+	cooled := entitiesrooms.Candidate{GameID: uuid.New().String(), Votes: 1, CooledDown: true, BroughtBy: owner}
+	large := entitiesrooms.Candidate{GameID: uuid.New().String(), Votes: 1, MinPlayers: 4, BroughtBy: owner}
+	brought := entitiesrooms.Candidate{GameID: uuid.New().String(), Votes: 1, BroughtBy: guest}
+
+	tests := []struct {
+		name       string
+		room       entitiesrooms.Room
+		candidates []entitiesrooms.Candidate
+		options    entitiesrooms.PickOptions
+		rerolled   bool
+		rerolls    int
+		want       string
+		wantErr    error
+		// unmarked - отмена результата снимается, потому что замену выбрать не удалось.
+		unmarked bool
+	}{
+		{
+			name:       "constraints of the result are reused",
+			room:       entitiesrooms.Room{RerollLimit: 1},
+			candidates: []entitiesrooms.Candidate{picked, cooled, large},
+			options:    entitiesrooms.PickOptions{IgnoreCooldown: true, Players: 2},
+			want:       cooled.GameID,
+		},
+		{
+			name:       "participants present at the pick are reused",
+			room:       entitiesrooms.Room{RerollLimit: 1, AvailableOnly: true},
+			candidates: []entitiesrooms.Candidate{picked, large, brought},
+			options:    entitiesrooms.PickOptions{AvailableOnly: true, Available: []string{guest}},
+			want:       brought.GameID,
+		},
+		{
+			name:       "reroll limit reached",
+			room:       entitiesrooms.Room{RerollLimit: 2},
+			candidates: []entitiesrooms.Candidate{picked, cooled},
+			rerolls:    2,
+			wantErr:    ErrRerollLimitReached,
+		},
+		{
+			name:       "rerolls are disabled",
+			candidates: []entitiesrooms.Candidate{picked, cooled},
+			wantErr:    ErrRerollLimitReached,
+		},
+		{
+			name:       "result is already rerolled",
+			room:       entitiesrooms.Room{RerollLimit: 1},
+			candidates: []entitiesrooms.Candidate{picked, cooled},
+			rerolled:   true,
+			wantErr:    ErrAlreadyRerolled,
+		},
+		{
+			name:       "no replacement left",
+			room:       entitiesrooms.Room{RerollLimit: 1},
+			candidates: []entitiesrooms.Candidate{picked, cooled},
+			wantErr:    ErrNoCandidates,
+			unmarked:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := entitiesrooms.Result{
+				ID:       uuid.New().String(),
+				GameID:   picked.GameID,
+				Strategy: entitiesrooms.PickStrategyWeighted,
+				BatchID:  uuid.New().String(),
+				PollID:   uuid.New().String(),
+				Options:  tt.options,
+			}
+			if tt.rerolled {
+				result.Reroll = &entitiesrooms.Reroll{By: owner}
+			}
+			repo := &fakeRepo{
+				candidates: tt.candidates,
+				results:    map[string]entitiesrooms.Result{result.ID: result},
+				rerolls:    tt.rerolls,
+			}
+			s := NewService(repo, fakeRooms{room: tt.room}, nil, fakePolls{
+				poll: entitiesrooms.Poll{ID: result.PollID, Status: entitiesrooms.PollStatusPicked},
+			})
+
+			spin, err := s.Reroll(context.Background(), uuid.New().String(), result.ID, owner, "")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.batches) != 0 {
+					t.Errorf("replacement was saved despite %v", tt.wantErr)
+				}
+				if (len(repo.unmarked) == 1) != tt.unmarked {
+					t.Errorf("unmarked = %v, want unmarked %v", repo.unmarked, tt.unmarked)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if spin.GameID != tt.want || spin.RerollOf != result.ID {
+				t.Errorf("reroll = %q of %q, want %q of %q", spin.GameID, spin.RerollOf, tt.want, result.ID)
+			}
+			batch := repo.batches[uuid.MustParse(spin.BatchID)]
+			if len(batch) != 1 || !reflect.DeepEqual(batch[0].Options, tt.options) {
+				t.Errorf("saved batch = %+v, want one game with options %+v", batch, tt.options)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package results
+
+import (
+	"errors"
+	"math/rand/v2"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+var ErrNoBallots = errors.New("no ranked ballots in the room")
+
+// RunoffRound - один раунд мгновенного второго тура.
+// Tally - число бюллетеней, у которых игра стоит первой среди оставшихся,
+// Exhausted - число бюллетеней, в которых не осталось ни одной игры.
+type RunoffRound struct {
+	Round      int            `json:"round"`
+	Tally      map[string]int `json:"tally"`
+	Exhausted  int            `json:"exhausted"`
+	Eliminated []string       `json:"eliminated,omitempty"`
+	Winner     string         `json:"winner,omitempty"`
+}
+
+// instantRunoff определяет победителя по ранжированным бюллетеням.
+// В каждом раунде выбывают игры с наименьшим числом первых мест; побеждает игра,
+// набравшая большинство неисчерпанных бюллетеней. Если все оставшиеся игры
+// набрали поровну, победитель выбирается случайно среди них.
+func instantRunoff(rng *rand.Rand, candidates []entitiesrooms.Candidate, ballots []entitiesrooms.Ballot) (string, []RunoffRound, error) {
+	if len(candidates) == 0 {
+		return "", nil, ErrNoCandidates
+	}
+	if len(ballots) == 0 {
+		return "", nil, ErrNoBallots
+	}
+
+	active := make([]string, 0, len(candidates))
+	isActive := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		active = append(active, c.GameID)
+		isActive[c.GameID] = true
+	}
+
+	var rounds []RunoffRound
+	for round := 1; ; round++ {
+		current := RunoffRound{Round: round, Tally: make(map[string]int, len(active))}
+		for _, gameID := range active {
+			current.Tally[gameID] = 0
+		}
+		for _, ballot := range ballots {
+			counted := false
+			for _, gameID := range ballot.Rankings {
+				if isActive[gameID] {
+					current.Tally[gameID]++
+					counted = true
+					break
+				}
+			}
+			if !counted {
+				current.Exhausted++
+			}
+		}
+
+		continuing := len(ballots) - current.Exhausted
+		lowest, highest := current.Tally[active[0]], current.Tally[active[0]]
+		leader := active[0]
+		for _, gameID := range active[1:] {
+			votes := current.Tally[gameID]
+			lowest = min(lowest, votes)
+			if votes > highest {
+				highest, leader = votes, gameID
+			}
+		}
+
+		if len(active) == 1 || highest*2 > continuing {
+			current.Winner = leader
+			return leader, append(rounds, current), nil
+		}
+
+		if lowest == highest {
+			current.Winner = active[rng.IntN(len(active))]
+			return current.Winner, append(rounds, current), nil
+		}
+
+		remaining := active[:0]
+		for _, gameID := range active {
+			if current.Tally[gameID] == lowest {
+				current.Eliminated = append(current.Eliminated, gameID)
+				isActive[gameID] = false
+				continue
+			}
+			remaining = append(remaining, gameID)
+		}
+		active = remaining
+		rounds = append(rounds, current)
+	}
+}
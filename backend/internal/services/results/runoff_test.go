@@ -0,0 +1,144 @@
+package results
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+func candidatesOf(gameIDs ...string) []entitiesrooms.Candidate {
+	res := make([]entitiesrooms.Candidate, 0, len(gameIDs))
+	for _, gameID := range gameIDs {
+		res = append(res, entitiesrooms.Candidate{GameID: gameID})
+	}
+	return res
+}
+
+func ballotsOf(rankings ...[]string) []entitiesrooms.Ballot {
+	res := make([]entitiesrooms.Ballot, 0, len(rankings))
+	for _, r := range rankings {
+		res = append(res, entitiesrooms.Ballot{Rankings: r})
+	}
+	return res
+}
+
+func TestInstantRunoff(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		ballots    [][]string
+		// winners - допустимые победители: больше одного при случайном выборе из равных.
+		winners       []string
+		rounds        int
+		eliminated    []string
+		lastExhausted int
+		wantErr       error
+	}{
+		{
+			name:    "no candidates",
+			ballots: [][]string{{"a"}},
+			wantErr: ErrNoCandidates,
+		},
+		{
+			name:       "no ballots",
+			candidates: []string{"a", "b"},
+			wantErr:    ErrNoBallots,
+		},
+		{
+			name:       "majority in the first round",
+			candidates: []string{"a", "b", "c"},
+			ballots:    [][]string{{"a", "b"}, {"a"}, {"b"}},
+			winners:    []string{"a"},
+			rounds:     1,
+		},
+		{
+			name:       "single candidate wins without votes",
+			candidates: []string{"a"},
+			ballots:    [][]string{{"b"}},
+			winners:    []string{"a"},
+			rounds:     1,
+			// Бюллетень ранжирует только игру вне розыгрыша.
+			lastExhausted: 1,
+		},
+		{
+			name:       "transfer after elimination",
+			candidates: []string{"a", "b", "c"},
+			ballots:    [][]string{{"a"}, {"a"}, {"b"}, {"b"}, {"c", "b"}},
+			winners:    []string{"b"},
+			rounds:     2,
+			eliminated: []string{"c"},
+		},
+		{
+			name:          "exhausted ballots do not count towards majority",
+			candidates:    []string{"a", "b", "c"},
+			ballots:       [][]string{{"a"}, {"a"}, {"b"}, {"c"}},
+			winners:       []string{"a"},
+			rounds:        2,
+			eliminated:    []string{"b", "c"},
+			lastExhausted: 2,
+		},
+		{
+			name:       "full tie is resolved among remaining games",
+			candidates: []string{"a", "b", "c"},
+			ballots:    [][]string{{"a"}, {"b"}, {"c"}},
+			winners:    []string{"a", "b", "c"},
+			rounds:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(1, 2))
+			winner, rounds, err := instantRunoff(rng, candidatesOf(tt.candidates...), ballotsOf(tt.ballots...))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Contains(tt.winners, winner) {
+				t.Errorf("winner = %q, want one of %v", winner, tt.winners)
+			}
+			if len(rounds) != tt.rounds {
+				t.Fatalf("rounds = %d, want %d", len(rounds), tt.rounds)
+			}
+
+			last := rounds[len(rounds)-1]
+			if last.Winner != winner {
+				t.Errorf("last round winner = %q, want %q", last.Winner, winner)
+			}
+			if last.Exhausted != tt.lastExhausted {
+				t.Errorf("last round exhausted = %d, want %d", last.Exhausted, tt.lastExhausted)
+			}
+			if tt.eliminated != nil && !slices.Equal(rounds[0].Eliminated, tt.eliminated) {
+				t.Errorf("eliminated in round 1 = %v, want %v", rounds[0].Eliminated, tt.eliminated)
+			}
+		})
+	}
+}
+
+func TestInstantRunoffIsDeterministic(t *testing.T) {
+	candidates := candidatesOf("a", "b", "c", "d")
+	ballots := ballotsOf([]string{"a"}, []string{"b"}, []string{"c"}, []string{"d"})
+
+	first, _, err := instantRunoff(rand.New(rand.NewPCG(7, 7)), candidates, ballots)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for range 10 {
+		again, _, err := instantRunoff(rand.New(rand.NewPCG(7, 7)), candidates, ballots)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if again != first {
+			t.Fatalf("winner = %q with the same seed, want %q", again, first)
+		}
+	}
+}
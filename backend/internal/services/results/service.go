@@ -2,38 +2,345 @@ package results
 
 import (
 	"context"
+	"encoding/hex"
+	"slices"
+	"sync"
 
 	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
 	repositoryresults "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/results"
+	serviceballots "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/ballots"
+	servicepolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/polls"
+	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
 	"github.com/google/uuid"
 )
 
 type ResultService interface {
-	PickResult(context.Context, string) (string, error)
+	PickResult(context.Context, string, PickOptions) (Pick, error)
 	GetLastResult(context.Context, string) (string, error)
-	GetAllResults(context.Context, string) ([]string, error)
+	GetAllResults(context.Context, string) ([]entitiesrooms.Lineup, error)
 	Delete(context.Context, string) error
 	Add(context.Context, entitiesrooms.Result) (entitiesrooms.Result, error)
+	Verify(context.Context, string, string) (Verification, error)
+	Spin(context.Context, string, string, PickOptions) (Spin, error)
+	Reroll(context.Context, string, string, string, string) (Spin, error)
+	Odds(context.Context, string, string, Constraints) (Odds, error)
+	PublishOdds(context.Context, string)
+	Restore(context.Context) error
+}
+
+// PickOptions - параметры розыгрыша. Пустая Strategy означает стратегию комнаты по умолчанию,
+// IgnoreCooldown разрешает выбирать игры, которые недавно выпадали,
+// Count - число разных игр в подборке (0 означает одну игру).
+// PollID выбирает раунд, по голосам которого идёт розыгрыш (пустой - текущий раунд),
+// Exclude исключает игры из розыгрыша, RerollOf - результат, который заменяет перевыбор.
+// Constraints оставляет в розыгрыше только игры, подходящие по составу, длительности и меткам
+// и, если нужно, только игры присутствующих участников.
+type PickOptions struct {
+	Strategy       string
+	IgnoreCooldown bool
+	Count          int
+	PollID         string
+	Exclude        []string
+	RerollOf       string
+	Constraints    Constraints
+}
+
+// Pick - итог выбора игры по голосам раунда PollID. GameID и Rounds относятся к первой
+// игре подборки Lineup, Rounds заполняется только для ранжированного голосования.
+// Tied - игры, разделившие первое место при выборе по большинству голосов,
+// RunoffOf - исходный раунд, если выбор сделан по дополнительному раунду,
+// RerollOf - результат, вместо которого игра выбрана перевыбором.
+// Seed - зерно розыгрыша; оно не отдаётся в ответах и раскрывается только событием pick.revealed
+// в конце церемонии, а обязательство Commitment на зерно и снимок публикуется до розыгрыша.
+// Options - параметры розыгрыша с учётом настроек комнаты, они сохраняются с результатом для перевыбора.
+type Pick struct {
+	GameID     string                     `json:"game_id"`
+	Strategy   string                     `json:"strategy"`
+	Rounds     []RunoffRound              `json:"rounds,omitempty"`
+	Lineup     []Draw                     `json:"lineup"`
+	Tied       []string                   `json:"tied,omitempty"`
+	PollID     string                     `json:"poll_id"`
+	RunoffOf   string                     `json:"runoff_of,omitempty"`
+	RerollOf   string                     `json:"reroll_of,omitempty"`
+	Seed       string                     `json:"-"`
+	Commitment string                     `json:"commitment"`
+	Snapshot   entitiesrooms.DrawSnapshot `json:"snapshot"`
+	Options    PickOptions                `json:"-"`
 }
 
 type Service struct {
-	repo repositoryresults.ResultRepository
+	repo          repositoryresults.ResultRepository
+	roomService   servicerooms.RoomService
+	ballotService serviceballots.BallotService
+	pollService   servicepolls.PollService
+	scheduler     Scheduler
+	presence      Presence
+	hub           hub.Hub
+
+	// spinning - комнаты, в которых этот процесс проводит церемонию выбора. Защищает от
+	// одновременных запросов; после перезапуска идущие церемонии видны по нераскрытым результатам.
+	spinMu   sync.Mutex
+	spinning map[string]bool
+}
+
+func NewService(repo repositoryresults.ResultRepository, roomService servicerooms.RoomService, ballotService serviceballots.BallotService, pollService servicepolls.PollService) *Service {
+	return &Service{
+		repo:          repo,
+		roomService:   roomService,
+		ballotService: ballotService,
+		pollService:   pollService,
+		spinning:      make(map[string]bool),
+	}
+}
+
+func (s *Service) SetHub(h hub.Hub) {
+	s.hub = h
+}
+
+func (s *Service) SetScheduler(scheduler Scheduler) {
+	s.scheduler = scheduler
 }
 
-func NewService(repo repositoryresults.ResultRepository) *Service {
-	return &Service{repo: repo}
+func (s *Service) SetPresence(presence Presence) {
+	s.presence = presence
 }
 
-func (s *Service) PickResult(ctx context.Context, roomID string) (string, error) {
+// PickResult выбирает игру комнаты с параметрами opts.
+// Зерно генерируется до сбора кандидатов, а обязательство на зерно вместе со снимком
+// публикует Spin до старта колеса. Сам розыгрыш детерминирован зерном и снимком,
+// поэтому его можно проверить через Verify, а подменить снимок или зерно после публикации нельзя.
+func (s *Service) PickResult(ctx context.Context, roomID string, opts PickOptions) (Pick, error) {
 	uuidRoomID, err := uuid.Parse(roomID)
 	if err != nil {
 		logger.Errorf(ctx, "PickResult invalid RoomID: %v", err)
 
-		return "", err
+		return Pick{}, err
+	}
+
+	count := opts.Count
+	if count == 0 {
+		count = 1
+	}
+	if count < 1 || count > MaxLineupSize {
+		logger.Errorf(ctx, "PickResult invalid count: %v", opts.Count)
+
+		return Pick{}, ErrInvalidCount
+	}
+
+	seed, err := newSeed()
+	if err != nil {
+		logger.Errorf(ctx, "PickResult seed error: %v", err)
+
+		return Pick{}, err
+	}
+
+	draft, err := s.prepare(ctx, uuidRoomID, opts)
+	if err != nil {
+		return Pick{}, err
+	}
+	if err := unmatched(draft); err != nil {
+		logger.Errorf(ctx, "PickResult constraints error: %v", err)
+
+		return Pick{}, err
+	}
+
+	commitment, err := commit(seed, draft.snapshot)
+	if err != nil {
+		logger.Errorf(ctx, "PickResult commitment error: %v", err)
+
+		return Pick{}, err
+	}
+	lineup, err := replay(draft.strategy, seed, draft.snapshot, count)
+	if err != nil {
+		return Pick{}, err
+	}
+
+	var tied []string
+	if draft.strategy == entitiesrooms.PickStrategyPlurality {
+		tied = leaders(draft.candidates)
+	}
+	return Pick{
+		GameID:     lineup[0].GameID,
+		Strategy:   draft.strategy,
+		Rounds:     lineup[0].Rounds,
+		Lineup:     lineup,
+		Tied:       tied,
+		PollID:     draft.poll.ID,
+		RunoffOf:   draft.poll.RunoffOf,
+		RerollOf:   opts.RerollOf,
+		Seed:       hex.EncodeToString(seed[:]),
+		Commitment: commitment,
+		Snapshot:   draft.snapshot,
+		Options:    draft.opts,
+	}, nil
+}
+
+// draft - подготовленный розыгрыш: раунд, стратегия, все игры комнаты с голосами раунда,
+// допущенные к розыгрышу кандидаты и снимок с их весами. По нему выбирается игра
+// и рассчитываются шансы игр, поэтому шансы всегда совпадают с настоящим розыгрышем.
+// fairness заполняется, если в комнате включён режим справедливости. opts - параметры
+// розыгрыша с учётом настроек комнаты.
+type draft struct {
+	room       entitiesrooms.Room
+	poll       entitiesrooms.Poll
+	opts       PickOptions
+	strategy   string
+	games      []entitiesrooms.Candidate
+	candidates []entitiesrooms.Candidate
+	fairness   []entitiesrooms.Fairness
+	snapshot   entitiesrooms.DrawSnapshot
+}
+
+// prepare собирает розыгрыш комнаты с параметрами opts (Count не учитывается).
+func (s *Service) prepare(ctx context.Context, uuidRoomID uuid.UUID, opts PickOptions) (draft, error) {
+	roomID := uuidRoomID.String()
+	room, err := s.roomService.GetByID(ctx, roomID)
+	if err != nil {
+		return draft{}, err
+	}
+
+	var poll entitiesrooms.Poll
+	if opts.PollID != "" {
+		poll, err = s.pollService.Get(ctx, roomID, opts.PollID)
+	} else {
+		poll, err = s.pollService.Current(ctx, roomID)
+	}
+	if err != nil {
+		return draft{}, err
+	}
+
+	// Дополнительный раунд по умолчанию решается большинством голосов.
+	requested := opts.Strategy
+	if requested == "" && poll.IsRunoff() {
+		requested = entitiesrooms.PickStrategyPlurality
+	}
+
+	strategy, err := resolveStrategy(ctx, room, requested)
+	if err != nil {
+		return draft{}, err
+	}
+
+	uuidPollID, err := uuid.Parse(poll.ID)
+	if err != nil {
+		logger.Errorf(ctx, "PickResult invalid PollID: %v", err)
+
+		return draft{}, err
+	}
+
+	// Перевыбор идёт с ограничениями исходного результата: отметки присутствия
+	// после выбора уже сняты, поэтому участники берутся из результата.
+	if opts.RerollOf == "" {
+		if room.AvailableOnly {
+			opts.Constraints.AvailableOnly = true
+		}
+		if opts.Constraints.AvailableOnly && s.presence != nil {
+			presence, err := s.presence.GetPresence(ctx, roomID)
+			if err != nil {
+				return draft{}, err
+			}
+			opts.Constraints.Available = presence.Available
+		}
+	}
+
+	games, err := s.repo.GetCandidates(ctx, repositoryresults.GetCandidatesParams{
+		RoomID:          uuidRoomID,
+		PollID:          uuidPollID,
+		CooldownResults: int32(room.CooldownResults),
+		CooldownDays:    int32(room.CooldownDays),
+	})
+	if err != nil {
+		return draft{}, err
+	}
+
+	// Ранжированное голосование считает бюллетени, а не очки, поэтому множители на него не влияют.
+	var fairness []entitiesrooms.Fairness
+	if room.Fairness && strategy != entitiesrooms.PickStrategyRanked {
+		games, fairness, err = s.withFairness(ctx, uuidRoomID, uuidPollID, games)
+		if err != nil {
+			return draft{}, err
+		}
+	}
+
+	candidates := make([]entitiesrooms.Candidate, 0, len(games))
+	for _, c := range games {
+		if exclusion(c, poll, opts) == "" {
+			candidates = append(candidates, c)
+		}
 	}
+	candidates = withoutGames(candidates, opts.Exclude)
 
-	return s.repo.PickResult(ctx, uuidRoomID)
+	snapshot, err := s.snapshot(ctx, poll, strategy, candidates)
+	if err != nil {
+		return draft{}, err
+	}
+
+	return draft{
+		room:       room,
+		poll:       poll,
+		opts:       opts,
+		strategy:   strategy,
+		games:      games,
+		candidates: candidates,
+		fairness:   fairness,
+		snapshot:   snapshot,
+	}, nil
+}
+
+// snapshot собирает входные данные розыгрыша: кандидатов с весами стратегии,
+// а для ранжированного голосования - бюллетени участников, поданные в раунде poll.
+func (s *Service) snapshot(ctx context.Context, poll entitiesrooms.Poll, strategy string, candidates []entitiesrooms.Candidate) (entitiesrooms.DrawSnapshot, error) {
+	snapshot := entitiesrooms.DrawSnapshot{
+		Candidates: make([]entitiesrooms.WeightedCandidate, 0, len(candidates)),
+	}
+
+	if strategy == entitiesrooms.PickStrategyRanked {
+		ballots, err := s.ballotService.GetForPoll(ctx, poll.ID)
+		if err != nil {
+			return entitiesrooms.DrawSnapshot{}, err
+		}
+
+		for _, c := range candidates {
+			snapshot.Candidates = append(snapshot.Candidates, entitiesrooms.WeightedCandidate{GameID: c.GameID})
+		}
+		snapshot.Ballots = make([][]string, 0, len(ballots))
+		for _, ballot := range ballots {
+			snapshot.Ballots = append(snapshot.Ballots, ballot.Rankings)
+		}
+		return snapshot, nil
+	}
+
+	pickStrategy, err := LookupStrategy(strategy)
+	if err != nil {
+		return entitiesrooms.DrawSnapshot{}, err
+	}
+
+	weights := pickStrategy.Weights(candidates)
+	for i, c := range candidates {
+		snapshot.Candidates = append(snapshot.Candidates, entitiesrooms.WeightedCandidate{
+			GameID: c.GameID,
+			Weight: weights[i],
+		})
+	}
+	return snapshot, nil
+}
+
+// resolveStrategy подставляет стратегию комнаты, если strategy не задана, и проверяет её.
+func resolveStrategy(ctx context.Context, room entitiesrooms.Room, strategy string) (string, error) {
+	if strategy == "" {
+		strategy = room.PickStrategy
+	}
+	if strategy == "" {
+		strategy = entitiesrooms.PickStrategyWeighted
+	}
+
+	if !entitiesrooms.IsValidPickStrategy(strategy) {
+		logger.Errorf(ctx, "PickResult unknown strategy: %v", strategy)
+
+		return "", ErrUnknownStrategy
+	}
+	return strategy, nil
 }
 
 func (s *Service) GetLastResult(ctx context.Context, roomID string) (string, error) {
@@ -51,7 +358,8 @@ func (s *Service) GetLastResult(ctx context.Context, roomID string) (string, err
 	return res.GameID, nil
 }
 
-func (s *Service) GetAllResults(ctx context.Context, roomID string) ([]string, error) {
+// GetAllResults возвращает историю выборов комнаты, сгруппированную в подборки.
+func (s *Service) GetAllResults(ctx context.Context, roomID string) ([]entitiesrooms.Lineup, error) {
 	uuidRoomID, err := uuid.Parse(roomID)
 	if err != nil {
 		logger.Errorf(ctx, "GetAllResults invalid RoomID: %v", err)
@@ -64,11 +372,7 @@ func (s *Service) GetAllResults(ctx context.Context, roomID string) ([]string, e
 		return nil, err
 	}
 
-	res := make([]string, 0, len(results))
-	for _, result := range results {
-		res = append(res, result.GameID)
-	}
-	return res, nil
+	return groupLineups(results), nil
 }
 
 func (s *Service) Delete(ctx context.Context, roomID string) error {
@@ -83,34 +387,190 @@ func (s *Service) Delete(ctx context.Context, roomID string) error {
 }
 
 func (s *Service) Add(ctx context.Context, result entitiesrooms.Result) (entitiesrooms.Result, error) {
+	params, err := addParams(ctx, result)
+	if err != nil {
+		return entitiesrooms.Result{}, err
+	}
+
+	return s.repo.Add(ctx, params)
+}
+
+// addParams проверяет ID результата и собирает параметры его сохранения.
+func addParams(ctx context.Context, result entitiesrooms.Result) (repositoryresults.AddParams, error) {
 	id, err := uuid.Parse(result.ID)
 	if err != nil {
 		logger.Errorf(ctx, "AddResult invalid ID: %v", err)
 
-		return entitiesrooms.Result{}, err
+		return repositoryresults.AddParams{}, err
 	}
 	gameID, err := uuid.Parse(result.GameID)
 	if err != nil {
 		logger.Errorf(ctx, "AddResult invalid GameID: %v", err)
 
-		return entitiesrooms.Result{}, err
+		return repositoryresults.AddParams{}, err
 	}
 	roomID, err := uuid.Parse(result.RoomID)
 	if err != nil {
 		logger.Errorf(ctx, "AddResult invalid RoomID: %v", err)
 
-		return entitiesrooms.Result{}, err
+		return repositoryresults.AddParams{}, err
 	}
 	chosenBy, err := uuid.Parse(result.ChosenBy)
 	if err != nil {
 		logger.Errorf(ctx, "AddResult invalid ChosenBy: %v", err)
 
-		return entitiesrooms.Result{}, err
+		return repositoryresults.AddParams{}, err
 	}
-	return s.repo.Add(ctx, repositoryresults.AddParams{
-		ID:       id,
-		GameID:   gameID,
-		RoomID:   roomID,
-		ChosenBy: chosenBy,
-	})
+	pollID, err := uuid.Parse(result.PollID)
+	if err != nil {
+		logger.Errorf(ctx, "AddResult invalid PollID: %v", err)
+
+		return repositoryresults.AddParams{}, err
+	}
+	var rerollOf uuid.NullUUID
+	if result.RerollOf != "" {
+		rerollOf.UUID, err = uuid.Parse(result.RerollOf)
+		if err != nil {
+			logger.Errorf(ctx, "AddResult invalid RerollOf: %v", err)
+
+			return repositoryresults.AddParams{}, err
+		}
+		rerollOf.Valid = true
+	}
+	// Результат без подборки образует подборку из одной игры.
+	batchID := id
+	if result.BatchID != "" {
+		batchID, err = uuid.Parse(result.BatchID)
+		if err != nil {
+			logger.Errorf(ctx, "AddResult invalid BatchID: %v", err)
+
+			return repositoryresults.AddParams{}, err
+		}
+	}
+	return repositoryresults.AddParams{
+		ID:         id,
+		GameID:     gameID,
+		RoomID:     roomID,
+		ChosenBy:   chosenBy,
+		Strategy:   result.Strategy,
+		Seed:       result.Seed,
+		Commitment: result.Commitment,
+		Snapshot:   result.Snapshot,
+		Options:    result.Options,
+		BatchID:    batchID,
+		Position:   int32(result.Position),
+		PollID:     pollID,
+		RerollOf:   rerollOf,
+		LandsAt:    result.LandsAt,
+	}, nil
+}
+
+// Verify повторяет сохранённый розыгрыш по раскрытому зерну и снимку кандидатов.
+// Снимок хранит ID игр на момент розыгрыша, поэтому выпавшая игра, которую потом слили
+// с другой, сравнивается с результатом по ID игры, в которую её слили.
+func (s *Service) Verify(ctx context.Context, roomID, resultID string) (Verification, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "VerifyResult invalid RoomID: %v", err)
+
+		return Verification{}, err
+	}
+
+	uuidResultID, err := uuid.Parse(resultID)
+	if err != nil {
+		logger.Errorf(ctx, "VerifyResult invalid ResultID: %v", err)
+
+		return Verification{}, ErrResultNotFound
+	}
+
+	result, err := s.repo.GetResult(ctx, uuidResultID, uuidRoomID)
+	if err != nil {
+		return Verification{}, err
+	}
+	if result.ID == "" {
+		return Verification{}, ErrResultNotFound
+	}
+	if result.Seed == "" {
+		return Verification{}, ErrNotVerifiable
+	}
+
+	seed, err := parseSeed(result.Seed)
+	if err != nil {
+		logger.Errorf(ctx, "VerifyResult invalid seed: %v; resultID: %v", err, resultID)
+
+		return Verification{}, ErrNotVerifiable
+	}
+
+	merges, err := s.repo.GetMerges(ctx, uuidRoomID)
+	if err != nil {
+		return Verification{}, err
+	}
+
+	commitment, err := commit(seed, result.Snapshot)
+	if err != nil {
+		logger.Errorf(ctx, "VerifyResult commitment error: %v; resultID: %v", err, resultID)
+
+		return Verification{}, err
+	}
+
+	verification := Verification{
+		ResultID:        result.ID,
+		GameID:          result.GameID,
+		Strategy:        result.Strategy,
+		Seed:            result.Seed,
+		Commitment:      result.Commitment,
+		CommitmentValid: commitment == result.Commitment,
+		Position:        result.Position,
+		Snapshot:        result.Snapshot,
+	}
+
+	// Результат на месте Position подборки проверяется повтором розыгрыша первых Position+1 игр.
+	// Ошибка повтора означает, что снимок не позволяет получить сохранённый результат.
+	lineup, err := replay(result.Strategy, seed, result.Snapshot, result.Position+1)
+	if err == nil {
+		verification.RecomputedGameID = lineup[result.Position].GameID
+		if target, ok := merges[verification.RecomputedGameID]; ok {
+			verification.RecomputedGameID = target
+		}
+		verification.Rounds = lineup[result.Position].Rounds
+	}
+	verification.Valid = verification.CommitmentValid && verification.RecomputedGameID == result.GameID
+	return verification, nil
+}
+
+// withoutGames исключает из розыгрыша игры gameIDs.
+func withoutGames(candidates []entitiesrooms.Candidate, gameIDs []string) []entitiesrooms.Candidate {
+	if len(gameIDs) == 0 {
+		return candidates
+	}
+
+	res := make([]entitiesrooms.Candidate, 0, len(candidates))
+	for _, c := range candidates {
+		if !slices.Contains(gameIDs, c.GameID) {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// Причины, по которым игра не участвует в розыгрыше.
+const (
+	ExclusionVeto     = "veto"
+	ExclusionCooldown = "cooldown"
+	ExclusionRunoff   = "runoff"
+)
+
+// exclusion возвращает, почему игра не участвует в розыгрыше раунда poll с параметрами opts:
+// на неё наложено вето, она недавно выпадала, не входит в дополнительный раунд
+// или не подходит под ограничения розыгрыша. Пустая строка - игра участвует.
+func exclusion(c entitiesrooms.Candidate, poll entitiesrooms.Poll, opts PickOptions) string {
+	switch {
+	case c.Vetoes > 0:
+		return ExclusionVeto
+	case c.CooledDown && !opts.IgnoreCooldown:
+		return ExclusionCooldown
+	case !poll.Allows(c.GameID):
+		return ExclusionRunoff
+	}
+	return opts.Constraints.exclusion(c)
 }
@@ -0,0 +1,54 @@
+package results
+
+import (
+	"testing"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+func TestExclusion(t *testing.T) {
+	open := entitiesrooms.Poll{ID: "poll", Status: entitiesrooms.PollStatusOpen}
+	runoff := entitiesrooms.Poll{ID: "runoff", Status: entitiesrooms.PollStatusOpen, RunoffOf: "poll", Candidates: []string{"azul"}}
+
+	tests := []struct {
+		name string
+		game entitiesrooms.Candidate
+		poll entitiesrooms.Poll
+		opts PickOptions
+		want string
+	}{
+		{name: "allowed", game: entitiesrooms.Candidate{GameID: "azul", Votes: 1}, poll: open},
+		{name: "vetoed", game: entitiesrooms.Candidate{GameID: "azul", Vetoes: 1}, poll: open, want: ExclusionVeto},
+		{name: "recently picked", game: entitiesrooms.Candidate{GameID: "azul", CooledDown: true}, poll: open, want: ExclusionCooldown},
+		{
+			name: "cooldown is ignored",
+			game: entitiesrooms.Candidate{GameID: "azul", CooledDown: true},
+			poll: open,
+			opts: PickOptions{IgnoreCooldown: true},
+		},
+		{
+			name: "veto wins over ignored cooldown",
+			game: entitiesrooms.Candidate{GameID: "azul", Vetoes: 1, CooledDown: true},
+			poll: open,
+			opts: PickOptions{IgnoreCooldown: true},
+			want: ExclusionVeto,
+		},
+		{name: "outside the runoff", game: entitiesrooms.Candidate{GameID: "catan"}, poll: runoff, want: ExclusionRunoff},
+		{name: "in the runoff", game: entitiesrooms.Candidate{GameID: "azul"}, poll: runoff},
+		{
+			name: "constraints are checked last",
+			game: entitiesrooms.Candidate{GameID: "azul", MinPlayers: 4},
+			poll: open,
+			opts: PickOptions{Constraints: Constraints{Players: 2}},
+			want: ExclusionPlayers,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exclusion(tt.game, tt.poll, tt.opts); got != tt.want {
+				t.Errorf("exclusion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package results
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
+	repositoryresults "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/results"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/utils/logger"
+	"github.com/google/uuid"
+)
+
+var ErrSpinInProgress = errors.New("pick is already in progress in the room")
+
+const (
+	// spinLead - запас времени, чтобы событие о старте успело дойти до всех клиентов.
+	spinLead = time.Second
+	// spinDuration - длительность анимации колеса.
+	spinDuration = 5 * time.Second
+	// landRetries - сколько раз повторяется раскрытие подборки, если оно не удалось.
+	landRetries = 3
+	// landRetryDelay - пауза перед повтором раскрытия.
+	landRetryDelay = 5 * time.Second
+)
+
+// Spin - розыгрыш с церемонией: клиенты запускают колесо в StartedAt,
+// а в LandsAt сервер объявляет победителя событием results.updated.
+// ID - результат первой игры подборки, BatchID объединяет все её результаты.
+// Если выбор закончился ничьей и открыт дополнительный раунд, заполняется только Runoff:
+// игра не сохраняется, обязательство не публикуется, а церемония пройдёт по итогам дополнительного раунда.
+type Spin struct {
+	Pick
+	ID        string              `json:"id"`
+	BatchID   string              `json:"batch_id"`
+	StartedAt time.Time           `json:"started_at"`
+	LandsAt   time.Time           `json:"lands_at"`
+	Runoff    *entitiesrooms.Poll `json:"runoff,omitempty"`
+}
+
+// MarshalJSON отдаёт только то, что участники знают до остановки колеса: выпавшие игры
+// объявляются событием results.updated, а зерно - событием pick.revealed.
+func (s Spin) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		ID         string                     `json:"id,omitempty"`
+		BatchID    string                     `json:"batch_id,omitempty"`
+		Count      int                        `json:"count"`
+		Strategy   string                     `json:"strategy"`
+		PollID     string                     `json:"poll_id"`
+		RerollOf   string                     `json:"reroll_of,omitempty"`
+		Commitment string                     `json:"commitment,omitempty"`
+		Snapshot   entitiesrooms.DrawSnapshot `json:"snapshot"`
+		StartedAt  time.Time                  `json:"started_at"`
+		LandsAt    time.Time                  `json:"lands_at"`
+		Tied       []string                   `json:"tied,omitempty"`
+		Runoff     *entitiesrooms.Poll        `json:"runoff,omitempty"`
+	}{
+		ID:         s.ID,
+		BatchID:    s.BatchID,
+		Count:      len(s.Lineup),
+		Strategy:   s.Strategy,
+		PollID:     s.PollID,
+		RerollOf:   s.RerollOf,
+		Commitment: s.Commitment,
+		Snapshot:   s.Snapshot,
+		StartedAt:  s.StartedAt,
+		LandsAt:    s.LandsAt,
+		Tied:       s.Tied,
+		Runoff:     s.Runoff,
+	})
+}
+
+// Spin выбирает и сохраняет игру, рассылая участникам комнаты события церемонии:
+// pick.committed с обязательством на зерно, pick.started с порядком кандидатов на колесе,
+// results.updated и pick.revealed в момент остановки колеса.
+// До остановки колеса результаты не раскрываются: их нет ни в истории, ни в последнем
+// результате, ни в проверке. Пока церемония не закончилась, новый выбор в комнате отклоняется.
+func (s *Service) Spin(ctx context.Context, roomID, chosenBy string, opts PickOptions) (Spin, error) {
+	if !s.beginSpin(roomID) {
+		return Spin{}, ErrSpinInProgress
+	}
+
+	spin, err := s.spin(ctx, roomID, chosenBy, opts)
+	if err != nil {
+		s.endSpin(roomID)
+
+		return Spin{}, err
+	}
+	if spin.Runoff != nil {
+		s.endSpin(roomID)
+
+		return spin, nil
+	}
+
+	s.scheduleLanding(roomID, spin.BatchID, spin.LandsAt)
+	return spin, nil
+}
+
+// Restore ставит объявление для всех нераскрытых подборок. Подборки, колесо которых
+// остановилось за время простоя сервера, объявляются сразу.
+func (s *Service) Restore(ctx context.Context) error {
+	pending, err := s.repo.GetUnrevealed(ctx)
+	if err != nil {
+		return err
+	}
+
+	scheduled := make(map[string]bool)
+	for _, result := range pending {
+		if scheduled[result.BatchID] {
+			continue
+		}
+		scheduled[result.BatchID] = true
+
+		s.beginSpin(result.RoomID)
+		s.scheduleLanding(result.RoomID, result.BatchID, result.LandsAt)
+	}
+	logger.Infof(ctx, "Restored %d pick landings", len(scheduled))
+	return nil
+}
+
+func (s *Service) spin(ctx context.Context, roomID, chosenBy string, opts PickOptions) (Spin, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		logger.Errorf(ctx, "Spin invalid RoomID: %v", err)
+
+		return Spin{}, err
+	}
+
+	// Церемония могла начаться до перезапуска сервера.
+	pending, err := s.repo.HasUnrevealed(ctx, uuidRoomID)
+	if err != nil {
+		return Spin{}, err
+	}
+	if pending {
+		return Spin{}, ErrSpinInProgress
+	}
+
+	pick, err := s.PickResult(ctx, roomID, opts)
+	if err != nil {
+		return Spin{}, err
+	}
+
+	runoff, started, err := s.startRunoff(ctx, roomID, chosenBy, pick)
+	if err != nil {
+		return Spin{}, err
+	}
+	if started {
+		return Spin{Pick: pick, Runoff: &runoff}, nil
+	}
+
+	startedAt := time.Now().Add(spinLead)
+	landsAt := startedAt.Add(spinDuration)
+
+	// Все игры подборки сохраняются с общими зерном и снимком, поэтому каждую можно проверить отдельно.
+	batchID := uuid.New()
+	batch := make([]repositoryresults.AddParams, 0, len(pick.Lineup))
+	for _, game := range pick.Lineup {
+		params, err := addParams(ctx, entitiesrooms.Result{
+			ID:         uuid.New().String(),
+			RoomID:     roomID,
+			GameID:     game.GameID,
+			ChosenBy:   chosenBy,
+			Strategy:   pick.Strategy,
+			Seed:       pick.Seed,
+			Commitment: pick.Commitment,
+			Snapshot:   pick.Snapshot,
+			Options:    pick.Options.stored(),
+			BatchID:    batchID.String(),
+			Position:   game.Position,
+			PollID:     pick.PollID,
+			RerollOf:   pick.RerollOf,
+			LandsAt:    landsAt,
+		})
+		if err != nil {
+			return Spin{}, err
+		}
+		batch = append(batch, params)
+	}
+
+	results, err := s.repo.AddBatch(ctx, batch)
+	if err != nil {
+		return Spin{}, err
+	}
+	for i, result := range results {
+		pick.Lineup[i].ID = result.ID
+	}
+
+	// Подборка без отметки раунда удаляется: иначе после перезапуска Restore объявил бы
+	// выбор, который завершился ошибкой.
+	if err := s.pollService.MarkPicked(ctx, pick.PollID); err != nil {
+		if err := s.repo.DeleteBatch(ctx, uuidRoomID, batchID); err != nil {
+			logger.Errorf(ctx, "Spin DeleteBatch error: %v; batchID: %v", err, batchID)
+		}
+
+		return Spin{}, err
+	}
+
+	spin := Spin{
+		Pick:      pick,
+		ID:        pick.Lineup[0].ID,
+		BatchID:   batchID.String(),
+		StartedAt: startedAt,
+		LandsAt:   landsAt,
+	}
+
+	// Обязательство публикуется только для сохранённого выбора: при ничьей с дополнительным
+	// раундом или ошибке сохранения зерно не будет раскрыто.
+	if s.hub != nil {
+		s.hub.Broadcast(roomID, hub.RoomEvent{
+			Type:   hub.EventPickCommitted,
+			RoomID: roomID,
+			Payload: map[string]any{
+				"commitment": spin.Commitment,
+				"strategy":   spin.Strategy,
+				"snapshot":   spin.Snapshot,
+			},
+		})
+		s.hub.Broadcast(roomID, hub.RoomEvent{
+			Type:   hub.EventPickStarted,
+			RoomID: roomID,
+			Payload: map[string]any{
+				"id":         spin.ID,
+				"batch_id":   spin.BatchID,
+				"count":      len(spin.Lineup),
+				"strategy":   spin.Strategy,
+				"commitment": spin.Commitment,
+				"candidates": spin.Snapshot.Candidates,
+				"started_at": spin.StartedAt,
+				"lands_at":   spin.LandsAt,
+			},
+		})
+	}
+	return spin, nil
+}
+
+func (s *Service) scheduleLanding(roomID, batchID string, landsAt time.Time) {
+	time.AfterFunc(time.Until(landsAt), func() {
+		s.land(roomID, batchID, 0)
+	})
+}
+
+// land раскрывает результаты подборки, объявляет победителя и раскрывает зерно,
+// завершая церемонию. Выпавшие игры и раунды восстанавливаются из сохранённых результатов,
+// поэтому объявление не зависит от того, перезапускался ли сервер.
+// Если раскрыть подборку не удалось, раскрытие повторяется, а после повторов
+// в комнату отправляется pick.failed и церемония завершается.
+func (s *Service) land(roomID, batchID string, attempt int) {
+	ctx := context.Background()
+
+	results, err := s.reveal(ctx, roomID, batchID)
+	if err != nil && attempt < landRetries {
+		logger.Errorf(ctx, "LandPick Reveal error: %v; batchID: %v; attempt: %v", err, batchID, attempt)
+		time.AfterFunc(landRetryDelay, func() {
+			s.land(roomID, batchID, attempt+1)
+		})
+
+		return
+	}
+
+	defer s.endSpin(roomID)
+
+	if err != nil {
+		logger.Errorf(ctx, "LandPick Reveal error: %v; batchID: %v", err, batchID)
+		s.failLanding(roomID, batchID, err)
+
+		return
+	}
+	// Подборку уже раскрыл другой запуск или она удалена: объявлять нечего.
+	if len(results) == 0 {
+		logger.Errorf(ctx, "LandPick no unrevealed results; batchID: %v", batchID)
+
+		return
+	}
+	slices.SortFunc(results, func(a, b entitiesrooms.Result) int {
+		return a.Position - b.Position
+	})
+
+	first := results[0]
+	lineup := make([]Draw, 0, len(results))
+	for _, result := range results {
+		lineup = append(lineup, Draw{ID: result.ID, Position: result.Position, GameID: result.GameID})
+	}
+
+	// Игра выбрана, вечер состоялся: отметки присутствия больше не нужны.
+	if s.presence != nil {
+		if err := s.presence.ResetPresence(ctx, roomID); err != nil {
+			logger.Errorf(ctx, "LandPick ResetPresence error: %v; roomID: %v", err, roomID)
+		}
+	}
+
+	// Раунды ранжированного голосования не хранятся: они повторяются по зерну и снимку.
+	if first.Strategy == entitiesrooms.PickStrategyRanked {
+		seed, err := parseSeed(first.Seed)
+		if err == nil {
+			replayed, err := replay(first.Strategy, seed, first.Snapshot, len(results))
+			if err == nil {
+				for i := range lineup {
+					lineup[i].Rounds = replayed[i].Rounds
+				}
+			}
+		}
+	}
+
+	if s.hub == nil {
+		return
+	}
+
+	s.hub.Broadcast(roomID, hub.RoomEvent{
+		Type:   hub.EventResultsUpdated,
+		RoomID: roomID,
+		Payload: map[string]any{
+			"id":       first.ID,
+			"batch_id": first.BatchID,
+			"game_id":  first.GameID,
+			"strategy": first.Strategy,
+			"rounds":   lineup[0].Rounds,
+			"lineup":   lineup,
+		},
+	})
+	s.hub.Broadcast(roomID, hub.RoomEvent{
+		Type:   hub.EventPickRevealed,
+		RoomID: roomID,
+		Payload: map[string]any{
+			"id":         first.ID,
+			"batch_id":   first.BatchID,
+			"game_id":    first.GameID,
+			"strategy":   first.Strategy,
+			"seed":       first.Seed,
+			"commitment": first.Commitment,
+		},
+	})
+
+	poll, err := s.pollService.Get(ctx, roomID, first.PollID)
+	if err != nil {
+		logger.Errorf(ctx, "LandPick GetPoll error: %v; pollID: %v", err, first.PollID)
+
+		return
+	}
+	if poll.RunoffOf != "" {
+		s.hub.Broadcast(roomID, hub.RoomEvent{
+			Type:   hub.EventRunoffFinished,
+			RoomID: roomID,
+			Payload: map[string]any{
+				"poll_id":   poll.ID,
+				"runoff_of": poll.RunoffOf,
+				"id":        first.ID,
+				"game_id":   first.GameID,
+			},
+		})
+	}
+}
+
+func (s *Service) reveal(ctx context.Context, roomID, batchID string) ([]entitiesrooms.Result, error) {
+	uuidRoomID, err := uuid.Parse(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	uuidBatchID, err := uuid.Parse(batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.Reveal(ctx, uuidRoomID, uuidBatchID)
+}
+
+// failLanding сообщает комнате, что результаты подборки не удалось раскрыть.
+func (s *Service) failLanding(roomID, batchID string, err error) {
+	if s.hub == nil {
+		return
+	}
+
+	s.hub.Broadcast(roomID, hub.RoomEvent{
+		Type:   hub.EventPickFailed,
+		RoomID: roomID,
+		Payload: map[string]any{
+			"batch_id": batchID,
+			"error":    err.Error(),
+		},
+	})
+}
+
+func (s *Service) beginSpin(roomID string) bool {
+	s.spinMu.Lock()
+	defer s.spinMu.Unlock()
+
+	if s.spinning[roomID] {
+		return false
+	}
+	s.spinning[roomID] = true
+	return true
+}
+
+func (s *Service) endSpin(roomID string) {
+	s.spinMu.Lock()
+	defer s.spinMu.Unlock()
+
+	delete(s.spinning, roomID)
+}
@@ -0,0 +1,293 @@
+package results
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
+	repositoryresults "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/results"
+	servicepolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/polls"
+	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
+	"github.com/google/uuid"
+)
+
+// fakeRepo хранит подборки так же, как репозиторий: AddBatch сохраняет подборку целиком,
+// DeleteBatch удаляет её по BatchID. Reveal завершается ошибкой revealErr.
+// results - раскрытые результаты комнаты, rerolls - число перевыборов за окно.
+type fakeRepo struct {
+	repositoryresults.ResultRepository
+	candidates []entitiesrooms.Candidate
+	batches    map[uuid.UUID][]repositoryresults.AddParams
+	revealErr  error
+	results    map[string]entitiesrooms.Result
+	rerolls    int
+	unmarked   []uuid.UUID
+}
+
+func (r *fakeRepo) HasUnrevealed(context.Context, uuid.UUID) (bool, error) {
+	return len(r.batches) > 0, nil
+}
+
+func (r *fakeRepo) GetCandidates(context.Context, repositoryresults.GetCandidatesParams) ([]entitiesrooms.Candidate, error) {
+	return r.candidates, nil
+}
+
+func (r *fakeRepo) AddBatch(_ context.Context, batch []repositoryresults.AddParams) ([]entitiesrooms.Result, error) {
+	res := make([]entitiesrooms.Result, 0, len(batch))
+	for _, params := range batch {
+		if r.batches == nil {
+			r.batches = make(map[uuid.UUID][]repositoryresults.AddParams)
+		}
+		r.batches[params.BatchID] = append(r.batches[params.BatchID], params)
+		res = append(res, entitiesrooms.Result{
+			ID:       params.ID.String(),
+			GameID:   params.GameID.String(),
+			BatchID:  params.BatchID.String(),
+			Position: int(params.Position),
+		})
+	}
+	return res, nil
+}
+
+func (r *fakeRepo) DeleteBatch(_ context.Context, _, batchID uuid.UUID) error {
+	delete(r.batches, batchID)
+	return nil
+}
+
+func (r *fakeRepo) Reveal(_ context.Context, _, batchID uuid.UUID) ([]entitiesrooms.Result, error) {
+	if r.revealErr != nil {
+		return nil, r.revealErr
+	}
+
+	res := make([]entitiesrooms.Result, 0, len(r.batches[batchID]))
+	for _, params := range r.batches[batchID] {
+		res = append(res, entitiesrooms.Result{
+			ID:       params.ID.String(),
+			GameID:   params.GameID.String(),
+			BatchID:  params.BatchID.String(),
+			Position: int(params.Position),
+			PollID:   params.PollID.String(),
+			Strategy: params.Strategy,
+		})
+	}
+	delete(r.batches, batchID)
+	return res, nil
+}
+
+type fakeRooms struct {
+	servicerooms.RoomService
+	room entitiesrooms.Room
+}
+
+func (f fakeRooms) GetByID(context.Context, string) (entitiesrooms.Room, error) {
+	return f.room, nil
+}
+
+type fakePolls struct {
+	servicepolls.PollService
+	poll    entitiesrooms.Poll
+	markErr error
+}
+
+func (f fakePolls) Current(context.Context, string) (entitiesrooms.Poll, error) {
+	return f.poll, nil
+}
+
+func (f fakePolls) Get(context.Context, string, string) (entitiesrooms.Poll, error) {
+	return f.poll, nil
+}
+
+func (f fakePolls) MarkPicked(context.Context, string) error {
+	return f.markErr
+}
+
+func (f fakePolls) OpenRunoff(_ context.Context, _, _, pollID string, gameIDs []string, deadline time.Time) (entitiesrooms.Poll, error) {
+	return entitiesrooms.Poll{
+		ID:         uuid.New().String(),
+		Status:     entitiesrooms.PollStatusOpen,
+		RunoffOf:   pollID,
+		Candidates: gameIDs,
+		Deadline:   deadline,
+	}, nil
+}
+
+type fakeScheduler struct{}
+
+func (fakeScheduler) Schedule(entitiesrooms.Poll) {}
+
+type fakeHub struct {
+	hub.Hub
+	events []hub.RoomEventType
+}
+
+func (h *fakeHub) Broadcast(_ string, evt hub.RoomEvent) {
+	h.events = append(h.events, evt.Type)
+}
+
+func TestSpin(t *testing.T) {
+	errMark := errors.New("mark picked failed")
+	candidates := []entitiesrooms.Candidate{
+		{GameID: uuid.New().String(), Votes: 1},
+		{GameID: uuid.New().String(), Votes: 2},
+		{GameID: uuid.New().String(), Votes: 3},
+	}
+
+	tied := []entitiesrooms.Candidate{
+		{GameID: uuid.New().String(), Votes: 2, Score: 2},
+		{GameID: uuid.New().String(), Votes: 2, Score: 2},
+	}
+	started := []hub.RoomEventType{hub.EventPickCommitted, hub.EventPickStarted}
+
+	tests := []struct {
+		name       string
+		room       entitiesrooms.Room
+		candidates []entitiesrooms.Candidate
+		count      int
+		markErr    error
+		wantErr    error
+		wantRunoff bool
+		want       []hub.RoomEventType
+	}{
+		{name: "single game", candidates: candidates, count: 1, want: started},
+		{name: "lineup", candidates: candidates, count: 3, want: started},
+		{
+			name:       "poll cannot be marked picked",
+			candidates: candidates,
+			count:      2,
+			markErr:    errMark,
+			wantErr:    errMark,
+		},
+		{
+			name:       "tie opens a runoff",
+			room:       entitiesrooms.Room{PickStrategy: entitiesrooms.PickStrategyPlurality, RunoffSeconds: 60},
+			candidates: tied,
+			count:      1,
+			wantRunoff: true,
+			want:       []hub.RoomEventType{hub.EventRunoffStarted},
+		},
+		{
+			name:       "tie without runoff is drawn",
+			room:       entitiesrooms.Room{PickStrategy: entitiesrooms.PickStrategyPlurality},
+			candidates: tied,
+			count:      1,
+			want:       started,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepo{candidates: tt.candidates}
+			h := &fakeHub{}
+			s := NewService(repo, fakeRooms{room: tt.room}, nil, fakePolls{
+				poll:    entitiesrooms.Poll{ID: uuid.New().String(), Status: entitiesrooms.PollStatusOpen},
+				markErr: tt.markErr,
+			})
+			s.SetHub(h)
+			s.SetScheduler(fakeScheduler{})
+
+			spin, err := s.spin(context.Background(), uuid.New().String(), uuid.New().String(), PickOptions{Count: tt.count})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.batches) != 0 {
+					t.Errorf("batch was kept despite %v", tt.wantErr)
+				}
+				if len(h.events) != 0 {
+					t.Errorf("events = %v, want none despite %v", h.events, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(h.events, tt.want) {
+				t.Errorf("events = %v, want %v", h.events, tt.want)
+			}
+			if tt.wantRunoff {
+				if spin.Runoff == nil || len(repo.batches) != 0 {
+					t.Errorf("runoff = %v, saved batches = %v, want a runoff and no saved games", spin.Runoff, repo.batches)
+				}
+				return
+			}
+
+			batch := repo.batches[uuid.MustParse(spin.BatchID)]
+			if len(repo.batches) != 1 || len(batch) != tt.count {
+				t.Fatalf("saved batches = %v, want one batch of %d games", repo.batches, tt.count)
+			}
+			for i, params := range batch {
+				if params.ID.String() != spin.Lineup[i].ID || params.GameID.String() != spin.Lineup[i].GameID {
+					t.Errorf("saved game %d = %v, want %+v", i, params, spin.Lineup[i])
+				}
+			}
+			if spin.ID != spin.Lineup[0].ID {
+				t.Errorf("spin id = %q, want the first game of the lineup %q", spin.ID, spin.Lineup[0].ID)
+			}
+		})
+	}
+}
+
+func TestLand(t *testing.T) {
+	errReveal := errors.New("reveal failed")
+	roomID := uuid.New().String()
+	batchID := uuid.New()
+	batch := []repositoryresults.AddParams{{ID: uuid.New(), GameID: uuid.New(), BatchID: batchID, PollID: uuid.New()}}
+
+	tests := []struct {
+		name      string
+		batch     []repositoryresults.AddParams
+		revealErr error
+		attempt   int
+		want      []hub.RoomEventType
+		// spinning - церемония продолжается до повтора раскрытия.
+		spinning bool
+	}{
+		{
+			name:  "results are revealed",
+			batch: batch,
+			want:  []hub.RoomEventType{hub.EventResultsUpdated, hub.EventPickRevealed},
+		},
+		{
+			name:      "reveal is retried",
+			batch:     batch,
+			revealErr: errReveal,
+			spinning:  true,
+		},
+		{
+			name:      "reveal fails after retries",
+			batch:     batch,
+			revealErr: errReveal,
+			attempt:   landRetries,
+			want:      []hub.RoomEventType{hub.EventPickFailed},
+		},
+		{
+			name: "nothing to reveal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepo{revealErr: tt.revealErr}
+			if tt.batch != nil {
+				repo.batches = map[uuid.UUID][]repositoryresults.AddParams{batchID: tt.batch}
+			}
+			h := &fakeHub{}
+			s := NewService(repo, fakeRooms{}, nil, fakePolls{})
+			s.SetHub(h)
+			s.beginSpin(roomID)
+
+			s.land(roomID, batchID.String(), tt.attempt)
+
+			if !slices.Equal(h.events, tt.want) {
+				t.Errorf("events = %v, want %v", h.events, tt.want)
+			}
+			if s.beginSpin(roomID) == tt.spinning {
+				t.Errorf("spinning = %v, want %v", !tt.spinning, tt.spinning)
+			}
+		})
+	}
+}
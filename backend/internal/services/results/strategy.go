@@ -0,0 +1,140 @@
+package results
+
+import (
+	"errors"
+	"math/rand/v2"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+)
+
+var (
+	ErrUnknownStrategy = errors.New("unknown pick strategy")
+	ErrNoCandidates    = errors.New("no games to pick from")
+)
+
+// PickStrategy определяет, с какими весами игры участвуют в розыгрыше.
+// Вес 0 исключает игру, итоговая вероятность игры - её вес, делённый на сумму весов.
+type PickStrategy interface {
+	Name() string
+	Weights([]entitiesrooms.Candidate) []float64
+}
+
+// LookupStrategy возвращает стратегию по имени.
+func LookupStrategy(name string) (PickStrategy, error) {
+	switch name {
+	case entitiesrooms.PickStrategyWeighted:
+		return WeightedStrategy{}, nil
+	case entitiesrooms.PickStrategyPlurality:
+		return PluralityStrategy{}, nil
+	case entitiesrooms.PickStrategyUniform:
+		return UniformStrategy{}, nil
+	case entitiesrooms.PickStrategyVotedOnly:
+		return VotedOnlyStrategy{}, nil
+	}
+	return nil, ErrUnknownStrategy
+}
+
+// WeightedStrategy - вероятность игры пропорциональна очкам голосов за неё (Score).
+// Если голосов нет ни у одной игры, выбор равновероятный.
+type WeightedStrategy struct{}
+
+func (WeightedStrategy) Name() string {
+	return entitiesrooms.PickStrategyWeighted
+}
+
+func (WeightedStrategy) Weights(candidates []entitiesrooms.Candidate) []float64 {
+	weights := make([]float64, len(candidates))
+	var total float64
+	for i, c := range candidates {
+		weights[i] = c.Score
+		total += weights[i]
+	}
+	if total == 0 {
+		return UniformStrategy{}.Weights(candidates)
+	}
+	return weights
+}
+
+// PluralityStrategy - побеждает игра с наибольшими очками голосов (Score), ничья решается случайно.
+type PluralityStrategy struct{}
+
+func (PluralityStrategy) Name() string {
+	return entitiesrooms.PickStrategyPlurality
+}
+
+func (PluralityStrategy) Weights(candidates []entitiesrooms.Candidate) []float64 {
+	var best float64
+	for _, c := range candidates {
+		best = max(best, c.Score)
+	}
+
+	weights := make([]float64, len(candidates))
+	for i, c := range candidates {
+		if c.Score == best {
+			weights[i] = 1
+		}
+	}
+	return weights
+}
+
+// UniformStrategy - все игры комнаты равновероятны, голоса не учитываются.
+type UniformStrategy struct{}
+
+func (UniformStrategy) Name() string {
+	return entitiesrooms.PickStrategyUniform
+}
+
+func (UniformStrategy) Weights(candidates []entitiesrooms.Candidate) []float64 {
+	weights := make([]float64, len(candidates))
+	for i := range weights {
+		weights[i] = 1
+	}
+	return weights
+}
+
+// VotedOnlyStrategy - равновероятный выбор среди игр, получивших хотя бы один голос.
+type VotedOnlyStrategy struct{}
+
+func (VotedOnlyStrategy) Name() string {
+	return entitiesrooms.PickStrategyVotedOnly
+}
+
+func (VotedOnlyStrategy) Weights(candidates []entitiesrooms.Candidate) []float64 {
+	weights := make([]float64, len(candidates))
+	for i, c := range candidates {
+		if c.Votes > 0 {
+			weights[i] = 1
+		}
+	}
+	return weights
+}
+
+// draw выбирает кандидата случайно пропорционально весам.
+func draw(rng *rand.Rand, candidates []entitiesrooms.Candidate, weights []float64) (string, error) {
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	if len(candidates) == 0 || total <= 0 {
+		return "", ErrNoCandidates
+	}
+
+	r := rng.Float64() * total
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		r -= w
+		if r < 0 {
+			return candidates[i].GameID, nil
+		}
+	}
+
+	// Защита от погрешности округления: берём последнего кандидата с ненулевым весом.
+	for i := len(weights) - 1; i >= 0; i-- {
+		if weights[i] > 0 {
+			return candidates[i].GameID, nil
+		}
+	}
+	return "", ErrNoCandidates
+}
@@ -0,0 +1,78 @@
+package results
+
+import (
+	"context"
+	"time"
+
+	entitiesrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
+)
+
+// Scheduler ставит таймер на срок голосования раунда.
+type Scheduler interface {
+	Schedule(entitiesrooms.Poll)
+}
+
+// leaders возвращает игры, разделившие первое место по голосам.
+// Если первое место у одной игры или голосов нет, ничьей нет.
+func leaders(candidates []entitiesrooms.Candidate) []string {
+	var best float64
+	for _, c := range candidates {
+		best = max(best, c.Score)
+	}
+	if best == 0 {
+		return nil
+	}
+
+	var res []string
+	for _, c := range candidates {
+		if c.Score == best {
+			res = append(res, c.GameID)
+		}
+	}
+	if len(res) < 2 {
+		return nil
+	}
+	return res
+}
+
+// startRunoff вместо случайного разрешения ничьей открывает дополнительный раунд
+// между играми, разделившими первое место. Раунд открывается, только если в комнате
+// задана его длительность, выбирается одна игра, это не перевыбор и ничья случилась
+// не в дополнительном раунде; иначе возвращается false и ничья решается розыгрышем.
+func (s *Service) startRunoff(ctx context.Context, roomID, chosenBy string, pick Pick) (entitiesrooms.Poll, bool, error) {
+	if len(pick.Tied) < 2 || len(pick.Lineup) != 1 || pick.RunoffOf != "" || pick.RerollOf != "" || s.scheduler == nil {
+		return entitiesrooms.Poll{}, false, nil
+	}
+
+	room, err := s.roomService.GetByID(ctx, roomID)
+	if err != nil {
+		return entitiesrooms.Poll{}, false, err
+	}
+	if room.RunoffSeconds <= 0 {
+		return entitiesrooms.Poll{}, false, nil
+	}
+
+	deadline := time.Now().Add(time.Duration(room.RunoffSeconds) * time.Second)
+	runoff, err := s.pollService.OpenRunoff(ctx, roomID, chosenBy, pick.PollID, pick.Tied, deadline)
+	if err != nil {
+		return entitiesrooms.Poll{}, false, err
+	}
+
+	// Итог дополнительного раунда подводит планировщик сроков голосования.
+	s.scheduler.Schedule(runoff)
+
+	if s.hub != nil {
+		s.hub.Broadcast(roomID, hub.RoomEvent{
+			Type:   hub.EventRunoffStarted,
+			RoomID: roomID,
+			Payload: map[string]any{
+				"poll_id":   runoff.ID,
+				"runoff_of": runoff.RunoffOf,
+				"game_ids":  runoff.Candidates,
+				"deadline":  runoff.Deadline,
+			},
+		})
+	}
+	return runoff, true, nil
+}
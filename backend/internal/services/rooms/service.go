@@ -84,8 +84,20 @@ func (s *Service) Update(ctx context.Context, room entitiesrooms.Room) (entities
 	}
 
 	params := repositoryrooms.UpdateParams{
-		ID:   id,
-		Name: room.Name,
+		ID:              id,
+		Name:            room.Name,
+		PickStrategy:    room.PickStrategy,
+		VetoLimit:       int32(room.VetoLimit),
+		VoteBudget:      int32(room.VoteBudget),
+		QuadraticVoting: room.QuadraticVoting,
+		CooldownResults: int32(room.CooldownResults),
+		CooldownDays:    int32(room.CooldownDays),
+		AutoPick:        room.AutoPick,
+		ReadyQuorum:     int32(room.ReadyQuorum),
+		RunoffSeconds:   int32(room.RunoffSeconds),
+		RerollLimit:     int32(room.RerollLimit),
+		Fairness:        room.Fairness,
+		AvailableOnly:   room.AvailableOnly,
 	}
 
 	result, err := s.repo.Update(ctx, params)
@@ -94,8 +106,20 @@ func (s *Service) Update(ctx context.Context, room entitiesrooms.Room) (entities
 			Type:   hub.EventRoomUpdated,
 			RoomID: room.ID,
 			Payload: map[string]any{
-				"id":   result.ID,
-				"name": result.Name,
+				"id":               result.ID,
+				"name":             result.Name,
+				"pick_strategy":    result.PickStrategy,
+				"veto_limit":       result.VetoLimit,
+				"vote_budget":      result.VoteBudget,
+				"quadratic_voting": result.QuadraticVoting,
+				"cooldown_results": result.CooldownResults,
+				"cooldown_days":    result.CooldownDays,
+				"auto_pick":        result.AutoPick,
+				"ready_quorum":     result.ReadyQuorum,
+				"runoff_seconds":   result.RunoffSeconds,
+				"reroll_limit":     result.RerollLimit,
+				"fairness":         result.Fairness,
+				"available_only":   result.AvailableOnly,
 			},
 		})
 	}
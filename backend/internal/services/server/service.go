@@ -7,21 +7,37 @@ import (
 
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/config"
 	handlersaccounts "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/handlers/accounts"
+	handlersballots "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/handlers/ballots"
+	handlersbrackets "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/handlers/brackets"
+	handlerscatalog "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/handlers/catalog"
 	handlersgames "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/handlers/games"
+	handlerslibrary "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/handlers/library"
 	handlersparticipants "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/handlers/participants"
+	handlerspolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/handlers/polls"
 	handlersrandom "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/handlers/random"
 	handlersrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/handlers/rooms"
 	handlersvotes "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/handlers/votes"
 	middlewares "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/middlewares"
+	repositoryballots "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/ballots"
+	repositorybrackets "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/brackets"
+	repositorycatalog "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/catalog"
 	repositorygames "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/games"
+	repositorylibrary "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/library"
 	repositoryparticipants "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/participants"
+	repositorypolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/polls"
 	repositoryrefreshtokens "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/refresh_tokens"
 	repositoryresults "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/results"
 	repositoryrooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/rooms"
 	repositoryusers "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/users"
 	repositoryvotes "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/votes"
+	serviceballots "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/ballots"
+	servicebrackets "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/brackets"
+	servicecatalog "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/catalog"
+	servicedeadlines "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/deadlines"
 	servicegames "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/games"
+	servicelibrary "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/library"
 	serviceparticipants "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/participants"
+	servicepolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/polls"
 	serviceresults "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/results"
 	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
 	servicetokens "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/tokens"
@@ -49,6 +65,11 @@ type Service struct {
 	resultsRepo      repositoryresults.ResultRepository
 	roomsRepo        repositoryrooms.RoomRepository
 	votesRepo        repositoryvotes.VoteRepository
+	ballotsRepo      repositoryballots.BallotRepository
+	bracketsRepo     repositorybrackets.BracketRepository
+	pollsRepo        repositorypolls.PollRepository
+	catalogRepo      repositorycatalog.CatalogRepository
+	libraryRepo      repositorylibrary.LibraryRepository
 
 	// servicess
 	userService        serviceusers.UserService
@@ -58,6 +79,12 @@ type Service struct {
 	resultService      serviceresults.ResultService
 	roomService        servicerooms.RoomService
 	voteService        servicevotes.VoteService
+	ballotService      serviceballots.BallotService
+	bracketService     servicebrackets.BracketService
+	pollService        servicepolls.PollService
+	deadlineService    servicedeadlines.DeadlineService
+	catalogService     servicecatalog.CatalogService
+	libraryService     servicelibrary.LibraryService
 
 	// handlers
 	// accounts handlers
@@ -70,19 +97,41 @@ type Service struct {
 	updateUserHandler handlersaccounts.UpdateUserHandler
 
 	// games handlers
-	addGameHandler    handlersgames.AddGameHandler
-	getGamesHandler   handlersgames.GetGamesHandler
-	deleteGameHandler handlersgames.DeleteGameHandler
+	addGameHandler     handlersgames.AddGameHandler
+	getGamesHandler    handlersgames.GetGamesHandler
+	deleteGameHandler  handlersgames.DeleteGameHandler
+	updateGameHandler  handlersgames.UpdateGameHandler
+	importGamesHandler handlersgames.ImportGamesHandler
+	exportGamesHandler handlersgames.ExportGamesHandler
+	mergeGamesHandler  handlersgames.MergeGamesHandler
+	gameOwnersHandler  handlersgames.GetGameOwnersHandler
+	restoreGameHandler handlersgames.RestoreGameHandler
+	archivedHandler    handlersgames.GetArchivedGamesHandler
+
+	// catalog handlers
+	searchCatalogHandler  handlerscatalog.SearchCatalogHandler
+	getCatalogGameHandler handlerscatalog.GetCatalogGameHandler
+
+	// library handlers
+	getLibraryHandler        handlerslibrary.GetLibraryHandler
+	addLibraryGameHandler    handlerslibrary.AddLibraryGameHandler
+	updateLibraryGameHandler handlerslibrary.UpdateLibraryGameHandler
+	deleteLibraryGameHandler handlerslibrary.DeleteLibraryGameHandler
 
 	// participants handlers
 	inviteHandler            handlersparticipants.InviteHandler
 	getParticipantsHandler   handlersparticipants.GetParticipantsHandler
 	deleteParticipantHandler handlersparticipants.DeleteParticipantHandler
+	setReadyHandler          handlersparticipants.SetReadyHandler
+	setPresentHandler        handlersparticipants.SetPresentHandler
 
 	// random handlers
-	getRandomHandler  handlersrandom.GetRandomHandler
-	getLastHandler    handlersrandom.GetLastHandler
-	getHistoryHandler handlersrandom.GetHistoryHandler
+	getRandomHandler    handlersrandom.GetRandomHandler
+	getLastHandler      handlersrandom.GetLastHandler
+	getHistoryHandler   handlersrandom.GetHistoryHandler
+	verifyResultHandler handlersrandom.VerifyResultHandler
+	rerollHandler       handlersrandom.RerollHandler
+	getOddsHandler      handlersrandom.GetOddsHandler
 
 	// rooms handlers
 	createRoomHandler  handlersrooms.CreateRoomHandler
@@ -96,6 +145,24 @@ type Service struct {
 	getVotesHandler   handlersvotes.GetVotesHandler
 	deleteVoteHandler handlersvotes.DeleteVoteHandler
 
+	// ballots handlers
+	submitBallotHandler handlersballots.SubmitBallotHandler
+	getBallotsHandler   handlersballots.GetBallotsHandler
+	deleteBallotHandler handlersballots.DeleteBallotHandler
+
+	// brackets handlers
+	startBracketHandler  handlersbrackets.StartBracketHandler
+	getBracketHandler    handlersbrackets.GetBracketHandler
+	voteMatchHandler     handlersbrackets.VoteMatchHandler
+	cancelBracketHandler handlersbrackets.CancelBracketHandler
+
+	// polls handlers
+	openPollHandler       handlerspolls.OpenPollHandler
+	closePollHandler      handlerspolls.ClosePollHandler
+	getPollsHandler       handlerspolls.GetPollsHandler
+	getCurrentPollHandler handlerspolls.GetCurrentPollHandler
+	setDeadlineHandler    handlerspolls.SetDeadlineHandler
+
 	// realtime
 	wsRoomHandler handlersrooms.WSRoomHandler
 	hub           hub.Hub
@@ -117,14 +184,25 @@ func NewServer(app *fiber.App, db *sql.DB, cfg config.AppConfig, ctx context.Con
 	resultsRepo := repositoryresults.NewRepository(db)
 	roomsRepo := repositoryrooms.NewRepository(db)
 	votesRepo := repositoryvotes.NewRepository(db)
+	ballotsRepo := repositoryballots.NewRepository(db)
+	bracketsRepo := repositorybrackets.NewRepository(db)
+	pollsRepo := repositorypolls.NewRepository(db)
+	catalogRepo := repositorycatalog.NewRepository(db)
+	libraryRepo := repositorylibrary.NewRepository(db)
 
 	userService := serviceusers.NewService(userRepo)
 	tokenService := servicetokens.NewService(cfg, refreshTokenRepo)
-	gameService := servicegames.NewService(gamesRepo)
-	participantService := serviceparticipants.NewService(participantsRepo)
-	resultService := serviceresults.NewService(resultsRepo)
+	catalogService := servicecatalog.NewService(catalogRepo)
+	libraryService := servicelibrary.NewService(libraryRepo, catalogService)
+	gameService := servicegames.NewService(gamesRepo, catalogService, libraryService)
 	roomService := servicerooms.NewService(roomsRepo)
-	voteService := servicevotes.NewService(votesRepo)
+	pollService := servicepolls.NewService(pollsRepo, roomService)
+	voteService := servicevotes.NewService(votesRepo, roomService, pollService)
+	ballotService := serviceballots.NewService(ballotsRepo, gameService, pollService)
+	resultService := serviceresults.NewService(resultsRepo, roomService, ballotService, pollService)
+	participantService := serviceparticipants.NewService(participantsRepo, roomService, resultService)
+	bracketService := servicebrackets.NewService(bracketsRepo, gameService, participantService)
+	deadlineService := servicedeadlines.NewService(pollService, resultService, roomService)
 
 	// accounts handlers
 	signUpHandler := handlersaccounts.NewSignupHandler(tokenService, userService)
@@ -139,16 +217,38 @@ func NewServer(app *fiber.App, db *sql.DB, cfg config.AppConfig, ctx context.Con
 	addGameHandler := handlersgames.NewAddGameHandler(gameService, participantService)
 	getGamesHandler := handlersgames.NewGetGamesHandler(gameService)
 	deleteGameHandler := handlersgames.NewDeleteGameHandler(gameService, participantService)
+	updateGameHandler := handlersgames.NewUpdateGameHandler(gameService, participantService)
+	importGamesHandler := handlersgames.NewImportGamesHandler(gameService)
+	exportGamesHandler := handlersgames.NewExportGamesHandler(gameService)
+	mergeGamesHandler := handlersgames.NewMergeGamesHandler(gameService)
+	gameOwnersHandler := handlersgames.NewGetGameOwnersHandler(libraryService)
+	restoreGameHandler := handlersgames.NewRestoreGameHandler(gameService)
+	archivedHandler := handlersgames.NewGetArchivedGamesHandler(gameService)
+
+	// catalog handlers
+	searchCatalogHandler := handlerscatalog.NewSearchCatalogHandler(catalogService)
+	getCatalogGameHandler := handlerscatalog.NewGetCatalogGameHandler(catalogService)
+
+	// library handlers
+	getLibraryHandler := handlerslibrary.NewGetLibraryHandler(libraryService)
+	addLibraryGameHandler := handlerslibrary.NewAddLibraryGameHandler(libraryService)
+	updateLibraryGameHandler := handlerslibrary.NewUpdateLibraryGameHandler(libraryService)
+	deleteLibraryGameHandler := handlerslibrary.NewDeleteLibraryGameHandler(libraryService)
 
 	// participants handlers
 	inviteHandler := handlersparticipants.NewInviteHandler(participantService, userService)
 	getParticipantsHandler := handlersparticipants.NewGetParticipantsHandler(participantService)
 	deleteParticipantHandler := handlersparticipants.NewDeleteParticipantHandler(participantService)
+	setReadyHandler := handlersparticipants.NewSetReadyHandler(participantService)
+	setPresentHandler := handlersparticipants.NewSetPresentHandler(participantService)
 
 	// random handlers
 	getRandomHandler := handlersrandom.NewGetRandomHandler(resultService)
 	getLastHandler := handlersrandom.NewGetLastHandler(resultService)
 	getHistoryHandler := handlersrandom.NewGetHistoryHandler(resultService)
+	verifyResultHandler := handlersrandom.NewVerifyResultHandler(resultService)
+	rerollHandler := handlersrandom.NewRerollHandler(resultService)
+	getOddsHandler := handlersrandom.NewGetOddsHandler(resultService)
 
 	// rooms handlers
 	createRoomHandler := handlersrooms.NewCreateRoomHandler(roomService, participantService)
@@ -159,9 +259,27 @@ func NewServer(app *fiber.App, db *sql.DB, cfg config.AppConfig, ctx context.Con
 
 	// votes handlers
 	addVoteHandler := handlersvotes.NewAddVoteHandler(voteService)
-	getVotesHandler := handlersvotes.NewGetVotesHandler(voteService)
+	getVotesHandler := handlersvotes.NewGetVotesHandler(voteService, roomService, pollService)
 	deleteVoteHandler := handlersvotes.NewDeleteVoteHandler(voteService)
 
+	// ballots handlers
+	submitBallotHandler := handlersballots.NewSubmitBallotHandler(ballotService)
+	getBallotsHandler := handlersballots.NewGetBallotsHandler(ballotService, pollService)
+	deleteBallotHandler := handlersballots.NewDeleteBallotHandler(ballotService)
+
+	// brackets handlers
+	startBracketHandler := handlersbrackets.NewStartBracketHandler(bracketService, roomService)
+	getBracketHandler := handlersbrackets.NewGetBracketHandler(bracketService)
+	voteMatchHandler := handlersbrackets.NewVoteMatchHandler(bracketService)
+	cancelBracketHandler := handlersbrackets.NewCancelBracketHandler(bracketService, roomService)
+
+	// polls handlers
+	openPollHandler := handlerspolls.NewOpenPollHandler(pollService, roomService)
+	closePollHandler := handlerspolls.NewClosePollHandler(pollService, roomService)
+	getPollsHandler := handlerspolls.NewGetPollsHandler(pollService)
+	getCurrentPollHandler := handlerspolls.NewGetCurrentPollHandler(pollService)
+	setDeadlineHandler := handlerspolls.NewSetDeadlineHandler(deadlineService, roomService)
+
 	// realtime hub & handler
 	h := hub.NewHubWS()
 	wsRoomHandler := handlersrooms.NewWSRoomHandler(h, tokenService)
@@ -171,6 +289,27 @@ func NewServer(app *fiber.App, db *sql.DB, cfg config.AppConfig, ctx context.Con
 	participantService.SetHub(h)
 	voteService.SetHub(h)
 	roomService.SetHub(h)
+	ballotService.SetHub(h)
+	resultService.SetHub(h)
+	bracketService.SetHub(h)
+	pollService.SetHub(h)
+	deadlineService.SetHub(h)
+	resultService.SetScheduler(deadlineService)
+	resultService.SetPresence(participantService)
+	pollService.SetPresence(participantService)
+	voteService.SetOdds(resultService)
+	ballotService.SetOdds(resultService)
+
+	// deadlines and match timers fire with events, so they are restored only after the hub is set
+	if err := deadlineService.Restore(ctx); err != nil {
+		return nil, fmt.Errorf("restore poll deadlines error: %v", err)
+	}
+	if err := bracketService.Restore(ctx); err != nil {
+		return nil, fmt.Errorf("restore bracket timers error: %v", err)
+	}
+	if err := resultService.Restore(ctx); err != nil {
+		return nil, fmt.Errorf("restore pick landings error: %v", err)
+	}
 
 	authMiddleware := middlewares.NewAuthMiddleware(tokenService)
 	checkRoomMiddleware := middlewares.NewCheckRoomMiddleware(roomService, participantService)
@@ -189,6 +328,11 @@ func NewServer(app *fiber.App, db *sql.DB, cfg config.AppConfig, ctx context.Con
 		resultsRepo:      resultsRepo,
 		roomsRepo:        roomsRepo,
 		votesRepo:        votesRepo,
+		ballotsRepo:      ballotsRepo,
+		bracketsRepo:     bracketsRepo,
+		pollsRepo:        pollsRepo,
+		catalogRepo:      catalogRepo,
+		libraryRepo:      libraryRepo,
 
 		// services
 		userService:        userService,
@@ -198,6 +342,12 @@ func NewServer(app *fiber.App, db *sql.DB, cfg config.AppConfig, ctx context.Con
 		resultService:      resultService,
 		roomService:        roomService,
 		voteService:        voteService,
+		ballotService:      ballotService,
+		bracketService:     bracketService,
+		pollService:        pollService,
+		deadlineService:    deadlineService,
+		catalogService:     catalogService,
+		libraryService:     libraryService,
 
 		// handlers
 		// accounts handlers
@@ -210,19 +360,41 @@ func NewServer(app *fiber.App, db *sql.DB, cfg config.AppConfig, ctx context.Con
 		updateUserHandler: *updateUserHandler,
 
 		// games handlers
-		addGameHandler:    *addGameHandler,
-		getGamesHandler:   *getGamesHandler,
-		deleteGameHandler: *deleteGameHandler,
+		addGameHandler:     *addGameHandler,
+		getGamesHandler:    *getGamesHandler,
+		deleteGameHandler:  *deleteGameHandler,
+		updateGameHandler:  *updateGameHandler,
+		importGamesHandler: *importGamesHandler,
+		exportGamesHandler: *exportGamesHandler,
+		mergeGamesHandler:  *mergeGamesHandler,
+		gameOwnersHandler:  *gameOwnersHandler,
+		restoreGameHandler: *restoreGameHandler,
+		archivedHandler:    *archivedHandler,
+
+		// catalog handlers
+		searchCatalogHandler:  *searchCatalogHandler,
+		getCatalogGameHandler: *getCatalogGameHandler,
+
+		// library handlers
+		getLibraryHandler:        *getLibraryHandler,
+		addLibraryGameHandler:    *addLibraryGameHandler,
+		updateLibraryGameHandler: *updateLibraryGameHandler,
+		deleteLibraryGameHandler: *deleteLibraryGameHandler,
 
 		// participants handlers
 		inviteHandler:            *inviteHandler,
 		getParticipantsHandler:   *getParticipantsHandler,
 		deleteParticipantHandler: *deleteParticipantHandler,
+		setReadyHandler:          *setReadyHandler,
+		setPresentHandler:        *setPresentHandler,
 
 		// random handlers
-		getRandomHandler:  *getRandomHandler,
-		getLastHandler:    *getLastHandler,
-		getHistoryHandler: *getHistoryHandler,
+		getRandomHandler:    *getRandomHandler,
+		getLastHandler:      *getLastHandler,
+		getHistoryHandler:   *getHistoryHandler,
+		verifyResultHandler: *verifyResultHandler,
+		rerollHandler:       *rerollHandler,
+		getOddsHandler:      *getOddsHandler,
 
 		// rooms handlers
 		createRoomHandler:  *createRoomHandler,
@@ -236,6 +408,24 @@ func NewServer(app *fiber.App, db *sql.DB, cfg config.AppConfig, ctx context.Con
 		getVotesHandler:   *getVotesHandler,
 		deleteVoteHandler: *deleteVoteHandler,
 
+		// ballots handlers
+		submitBallotHandler: *submitBallotHandler,
+		getBallotsHandler:   *getBallotsHandler,
+		deleteBallotHandler: *deleteBallotHandler,
+
+		// brackets handlers
+		startBracketHandler:  *startBracketHandler,
+		getBracketHandler:    *getBracketHandler,
+		voteMatchHandler:     *voteMatchHandler,
+		cancelBracketHandler: *cancelBracketHandler,
+
+		// polls handlers
+		openPollHandler:       *openPollHandler,
+		closePollHandler:      *closePollHandler,
+		getPollsHandler:       *getPollsHandler,
+		getCurrentPollHandler: *getCurrentPollHandler,
+		setDeadlineHandler:    *setDeadlineHandler,
+
 		// realtime
 		wsRoomHandler: *wsRoomHandler,
 		hub:           h,
@@ -276,10 +466,20 @@ func (s *Service) configure() error {
 	authApi.Get("/user/by-name", s.getByNameHandler.HandleGetByName)
 	authApi.Put("/user", s.updateUserHandler.HandleUpdateUser)
 
+	// Library routes
+	authApi.Get("/user/library", s.getLibraryHandler.Handle)
+	authApi.Post("/user/library", s.addLibraryGameHandler.Handle)
+	authApi.Put("/user/library/:library_game_id", s.updateLibraryGameHandler.Handle)
+	authApi.Delete("/user/library/:library_game_id", s.deleteLibraryGameHandler.Handle)
+
 	// Room routes
 	authApi.Post("/rooms", s.createRoomHandler.Handle)
 	authApi.Get("/rooms", s.getAllRoomsHandler.HandleGetAllRooms)
 
+	// Catalog routes
+	authApi.Get("/catalog", s.searchCatalogHandler.Handle)
+	authApi.Get("/catalog/:catalog_id", s.getCatalogGameHandler.Handle)
+
 	// Room-specific routes (with room middleware)
 	roomApi := authApi.Group("/rooms/:room_id")
 	roomApi.Use(s.checkRoomMiddleware.Handle)
@@ -293,21 +493,51 @@ func (s *Service) configure() error {
 	roomApi.Post("/games", s.addGameHandler.Handle)
 	roomApi.Get("/games", s.getGamesHandler.Handle)
 	roomApi.Delete("/games/:game_id", s.deleteGameHandler.Handle)
+	roomApi.Put("/games/:game_id", s.updateGameHandler.Handle)
+	roomApi.Post("/games/import", s.importGamesHandler.Handle)
+	roomApi.Get("/games/export", s.exportGamesHandler.Handle)
+	roomApi.Get("/games/owners", s.gameOwnersHandler.Handle)
+	roomApi.Get("/games/archived", s.archivedHandler.Handle)
+	roomApi.Post("/games/:game_id/restore", s.restoreGameHandler.Handle)
+	roomApi.Post("/games/:game_id/merge", s.mergeGamesHandler.Handle)
 
 	// Participants routes
 	roomApi.Post("/participants", s.inviteHandler.Handle)
 	roomApi.Get("/participants", s.getParticipantsHandler.Handle)
 	roomApi.Delete("/participants", s.deleteParticipantHandler.Handle)
+	roomApi.Put("/ready", s.setReadyHandler.Handle)
+	roomApi.Put("/present", s.setPresentHandler.Handle)
 
 	// Votes routes
 	roomApi.Post("/votes/", s.addVoteHandler.Handle)
 	roomApi.Get("/votes", s.getVotesHandler.Handle)
 	roomApi.Delete("/votes/:vote_id", s.deleteVoteHandler.Handle)
 
+	// Ranked ballots routes
+	roomApi.Put("/ballot", s.submitBallotHandler.Handle)
+	roomApi.Get("/ballots", s.getBallotsHandler.Handle)
+	roomApi.Delete("/ballot", s.deleteBallotHandler.Handle)
+
+	// Bracket routes
+	roomApi.Post("/bracket", s.startBracketHandler.Handle)
+	roomApi.Get("/bracket", s.getBracketHandler.Handle)
+	roomApi.Delete("/bracket", s.cancelBracketHandler.Handle)
+	roomApi.Post("/bracket/matches/:match_id/votes", s.voteMatchHandler.Handle)
+
+	// Polls routes
+	roomApi.Post("/polls", s.openPollHandler.Handle)
+	roomApi.Get("/polls", s.getPollsHandler.Handle)
+	roomApi.Get("/polls/current", s.getCurrentPollHandler.Handle)
+	roomApi.Post("/polls/current/close", s.closePollHandler.Handle)
+	roomApi.Put("/polls/current/deadline", s.setDeadlineHandler.Handle)
+
 	// Random routes
 	roomApi.Get("/random", s.getRandomHandler.Handle)
 	roomApi.Get("/random/last", s.getLastHandler.Handle)
 	roomApi.Get("/random/history", s.getHistoryHandler.Handle)
+	roomApi.Get("/random/odds", s.getOddsHandler.Handle)
+	roomApi.Get("/random/:result_id/verify", s.verifyResultHandler.Handle)
+	roomApi.Post("/random/:result_id/reroll", s.rerollHandler.Handle)
 
 	// WebSocket route for realtime room updates
 	// roomApi.Get("/ws", s.wsRoomHandler.Handle, websocket.New(s.wsRoomHandler.Conn))
@@ -2,33 +2,61 @@ package votes
 
 import (
 	"context"
+	"errors"
 
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
 	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/hub"
 	voterepository "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/votes"
+	servicepolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/polls"
+	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
 	"github.com/google/uuid"
 )
 
+var (
+	ErrInvalidKind      = errors.New("unknown vote kind")
+	ErrVetoLimitReached = errors.New("veto limit reached")
+	ErrInvalidPoints    = errors.New("invalid number of points")
+	ErrBudgetExceeded   = errors.New("vote budget exceeded")
+	ErrVotingClosed     = errors.New("voting in the poll is closed")
+	ErrNotInRunoff      = errors.New("game is not in the runoff")
+	ErrGameNotFound     = errors.New("game not found")
+)
+
+// OddsPublisher пересчитывает и рассылает шансы игр комнаты после изменения голосов.
+type OddsPublisher interface {
+	PublishOdds(context.Context, string)
+}
+
 type VoteService interface {
 	Add(context.Context, rooms.Vote) (rooms.Vote, error)
 	Get(context.Context, string) (rooms.Vote, error)
-	GetForRoom(context.Context, string) ([]rooms.Vote, error)
+	GetForPoll(context.Context, string) ([]rooms.Vote, error)
 	Delete(context.Context, string, string) error
+	GetSpent(context.Context, string, string) (int, error)
 }
 
+// Service принимает голоса в текущем раунде комнаты. Лимит вето и бюджет очков
+// считаются в пределах раунда.
 type Service struct {
-	repo voterepository.VoteRepository
-	hub  hub.Hub
+	repo        voterepository.VoteRepository
+	roomService servicerooms.RoomService
+	pollService servicepolls.PollService
+	odds        OddsPublisher
+	hub         hub.Hub
 }
 
-func NewService(repo voterepository.VoteRepository) *Service {
-	return &Service{repo: repo}
+func NewService(repo voterepository.VoteRepository, roomService servicerooms.RoomService, pollService servicepolls.PollService) *Service {
+	return &Service{repo: repo, roomService: roomService, pollService: pollService}
 }
 
 func (s *Service) SetHub(h hub.Hub) {
 	s.hub = h
 }
 
+func (s *Service) SetOdds(odds OddsPublisher) {
+	s.odds = odds
+}
+
 func (s *Service) Add(ctx context.Context, vote rooms.Vote) (rooms.Vote, error) {
 	id, err := uuid.Parse(vote.ID)
 	if err != nil {
@@ -50,12 +78,86 @@ func (s *Service) Add(ctx context.Context, vote rooms.Vote) (rooms.Vote, error)
 		return rooms.Vote{}, err
 	}
 
+	if vote.Kind == "" {
+		vote.Kind = rooms.VoteKindApprove
+	}
+	if !vote.IsValid() {
+		return rooms.Vote{}, ErrInvalidKind
+	}
+
+	if vote.Points == 0 {
+		vote.Points = 1
+	}
+	if vote.Points < 0 {
+		return rooms.Vote{}, ErrInvalidPoints
+	}
+
+	room, err := s.roomService.GetByID(ctx, vote.RoomID)
+	if err != nil {
+		return rooms.Vote{}, err
+	}
+
+	poll, err := s.pollService.Current(ctx, vote.RoomID)
+	if err != nil {
+		return rooms.Vote{}, err
+	}
+	if !poll.IsOpen() {
+		return rooms.Vote{}, ErrVotingClosed
+	}
+	if !poll.Allows(gameID.String()) {
+		return rooms.Vote{}, ErrNotInRunoff
+	}
+
+	pollID, err := uuid.Parse(poll.ID)
+	if err != nil {
+		return rooms.Vote{}, err
+	}
+
+	// Лимит вето и бюджет проверяются в транзакции добавления голоса, чтобы параллельные
+	// голоса участника не прошли проверку одновременно.
+	var check func(voterepository.Spent) error
+	switch vote.Kind {
+	case rooms.VoteKindVeto:
+		if vote.Points != 1 {
+			return rooms.Vote{}, ErrInvalidPoints
+		}
+
+		check = func(spent voterepository.Spent) error {
+			if spent.Vetoes >= int64(room.VetoLimit) {
+				return ErrVetoLimitReached
+			}
+			return nil
+		}
+	case rooms.VoteKindApprove:
+		if room.VoteBudget == 0 {
+			// Без бюджета действует правило "один участник - один голос за игру".
+			if vote.Points != 1 {
+				return rooms.Vote{}, ErrInvalidPoints
+			}
+			break
+		}
+
+		check = func(spent voterepository.Spent) error {
+			if cost(room, spent)+room.VoteCost(vote.Points) > room.VoteBudget {
+				return ErrBudgetExceeded
+			}
+			return nil
+		}
+	}
+
 	result, err := s.repo.Add(ctx, voterepository.AddParams{
 		ID:     id,
 		RoomID: roomID,
 		GameID: gameID,
 		UserID: userID,
+		Kind:   vote.Kind,
+		Points: int32(vote.Points),
+		PollID: pollID,
+		Check:  check,
 	})
+	if err == nil && result.ID == "" {
+		return rooms.Vote{}, ErrGameNotFound
+	}
 	if err == nil && s.hub != nil {
 		s.hub.Broadcast(vote.RoomID, hub.RoomEvent{
 			Type:   hub.EventVoteAdded,
@@ -64,9 +166,15 @@ func (s *Service) Add(ctx context.Context, vote rooms.Vote) (rooms.Vote, error)
 				"id":      result.ID,
 				"game_id": result.GameID,
 				"user_id": result.UserID,
+				"kind":    result.Kind,
+				"points":  result.Points,
+				"poll_id": result.PollID,
 			},
 		})
 	}
+	if err == nil && s.odds != nil {
+		s.odds.PublishOdds(ctx, vote.RoomID)
+	}
 	return result, err
 }
 
@@ -79,13 +187,13 @@ func (s *Service) Get(ctx context.Context, id string) (rooms.Vote, error) {
 	return s.repo.Get(ctx, uuidID)
 }
 
-func (s *Service) GetForRoom(ctx context.Context, roomID string) ([]rooms.Vote, error) {
-	uuidRoomID, err := uuid.Parse(roomID)
+func (s *Service) GetForPoll(ctx context.Context, pollID string) ([]rooms.Vote, error) {
+	uuidPollID, err := uuid.Parse(pollID)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.repo.GetForRoom(ctx, uuidRoomID)
+	return s.repo.GetForPoll(ctx, uuidPollID)
 }
 
 func (s *Service) Delete(ctx context.Context, id string, roomID string) error {
@@ -94,15 +202,99 @@ func (s *Service) Delete(ctx context.Context, id string, roomID string) error {
 		return err
 	}
 
+	vote, err := s.repo.Get(ctx, uuidID)
+	if err != nil {
+		return err
+	}
+
+	// Голоса закрытых раундов остаются в истории без изменений.
+	poll, err := s.pollService.Get(ctx, roomID, vote.PollID)
+	if err != nil {
+		return err
+	}
+	if !poll.IsOpen() {
+		return ErrVotingClosed
+	}
+
 	err = s.repo.Delete(ctx, uuidID)
 	if err == nil && s.hub != nil {
 		s.hub.Broadcast(roomID, hub.RoomEvent{
 			Type:   hub.EventVoteDeleted,
 			RoomID: roomID,
 			Payload: map[string]any{
-				"id": id,
+				"id":      id,
+				"game_id": vote.GameID,
+				"kind":    vote.Kind,
 			},
 		})
 	}
+	if err == nil && s.odds != nil {
+		s.odds.PublishOdds(ctx, roomID)
+	}
 	return err
 }
+
+// GetSpent возвращает, сколько очков бюджета комнаты потратил участник в текущем раунде.
+func (s *Service) GetSpent(ctx context.Context, roomID, userID string) (int, error) {
+	uuidUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	room, err := s.roomService.GetByID(ctx, roomID)
+	if err != nil {
+		return 0, err
+	}
+
+	poll, err := s.pollService.Current(ctx, roomID)
+	if err != nil {
+		return 0, err
+	}
+
+	pollID, err := uuid.Parse(poll.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.spent(ctx, room, pollID, uuidUserID)
+}
+
+func (s *Service) spent(ctx context.Context, room rooms.Room, pollID, userID uuid.UUID) (int, error) {
+	spent, err := s.repo.GetSpent(ctx, pollID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return cost(room, spent), nil
+}
+
+// cost возвращает, сколько очков бюджета комнаты стоят уже отданные голоса.
+func cost(room rooms.Room, spent voterepository.Spent) int {
+	if room.QuadraticVoting {
+		return int(spent.QuadraticPoints)
+	}
+	return int(spent.Points)
+}
+
+// Tally подсчитывает голоса по играм в порядке первого появления игры в списке.
+func Tally(votes []rooms.Vote) []rooms.VoteTally {
+	index := make(map[string]int)
+	tallies := make([]rooms.VoteTally, 0)
+	for _, vote := range votes {
+		i, ok := index[vote.GameID]
+		if !ok {
+			i = len(tallies)
+			index[vote.GameID] = i
+			tallies = append(tallies, rooms.VoteTally{GameID: vote.GameID})
+		}
+
+		switch vote.Kind {
+		case rooms.VoteKindVeto:
+			tallies[i].Vetoes++
+		default:
+			tallies[i].Voters++
+			tallies[i].Points += vote.Points
+		}
+	}
+	return tallies
+}
@@ -0,0 +1,216 @@
+package votes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/entities/rooms"
+	voterepository "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/repositories/votes"
+	servicepolls "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/polls"
+	servicerooms "code.mipt.ru/fullstack2025a/serdechnyjgl-project/internal/services/rooms"
+	"github.com/google/uuid"
+)
+
+// fakeRepo проверяет голос так же, как репозиторий в транзакции: Check получает
+// уже отданные участником голоса spent, и при ошибке голос не сохраняется.
+type fakeRepo struct {
+	voterepository.VoteRepository
+	spent       voterepository.Spent
+	missingGame bool
+	added       []voterepository.AddParams
+}
+
+func (r *fakeRepo) Add(_ context.Context, params voterepository.AddParams) (rooms.Vote, error) {
+	if params.Check != nil {
+		if err := params.Check(r.spent); err != nil {
+			return rooms.Vote{}, err
+		}
+	}
+	if r.missingGame {
+		return rooms.Vote{}, nil
+	}
+
+	r.added = append(r.added, params)
+	return rooms.Vote{
+		ID:     params.ID.String(),
+		RoomID: params.RoomID.String(),
+		GameID: params.GameID.String(),
+		UserID: params.UserID.String(),
+		Kind:   params.Kind,
+		Points: int(params.Points),
+		PollID: params.PollID.String(),
+	}, nil
+}
+
+type fakeRooms struct {
+	servicerooms.RoomService
+	room rooms.Room
+}
+
+func (f fakeRooms) GetByID(context.Context, string) (rooms.Room, error) {
+	return f.room, nil
+}
+
+type fakePolls struct {
+	servicepolls.PollService
+	poll rooms.Poll
+}
+
+func (f fakePolls) Current(context.Context, string) (rooms.Poll, error) {
+	return f.poll, nil
+}
+
+func TestAdd(t *testing.T) {
+	gameID := uuid.New().String()
+	openPoll := rooms.Poll{ID: uuid.New().String(), Status: rooms.PollStatusOpen}
+
+	tests := []struct {
+		name        string
+		room        rooms.Room
+		poll        rooms.Poll
+		spent       voterepository.Spent
+		missingGame bool
+		kind        string
+		points      int
+		wantErr     error
+	}{
+		{
+			name:   "approve without budget",
+			poll:   openPoll,
+			kind:   rooms.VoteKindApprove,
+			points: 1,
+		},
+		{
+			name:    "several points without budget",
+			poll:    openPoll,
+			kind:    rooms.VoteKindApprove,
+			points:  2,
+			wantErr: ErrInvalidPoints,
+		},
+		{
+			name:  "veto under the limit",
+			room:  rooms.Room{VetoLimit: 2},
+			poll:  openPoll,
+			spent: voterepository.Spent{Vetoes: 1},
+			kind:  rooms.VoteKindVeto,
+		},
+		{
+			name:    "veto limit reached",
+			room:    rooms.Room{VetoLimit: 2},
+			poll:    openPoll,
+			spent:   voterepository.Spent{Vetoes: 2},
+			kind:    rooms.VoteKindVeto,
+			wantErr: ErrVetoLimitReached,
+		},
+		{
+			name:    "vetoes are disabled",
+			poll:    openPoll,
+			kind:    rooms.VoteKindVeto,
+			wantErr: ErrVetoLimitReached,
+		},
+		{
+			name:    "veto with points",
+			room:    rooms.Room{VetoLimit: 2},
+			poll:    openPoll,
+			kind:    rooms.VoteKindVeto,
+			points:  2,
+			wantErr: ErrInvalidPoints,
+		},
+		{
+			name:   "points fit the budget",
+			room:   rooms.Room{VoteBudget: 5},
+			poll:   openPoll,
+			spent:  voterepository.Spent{Points: 2, QuadraticPoints: 4},
+			kind:   rooms.VoteKindApprove,
+			points: 3,
+		},
+		{
+			name:    "budget exceeded",
+			room:    rooms.Room{VoteBudget: 5},
+			poll:    openPoll,
+			spent:   voterepository.Spent{Points: 3, QuadraticPoints: 9},
+			kind:    rooms.VoteKindApprove,
+			points:  3,
+			wantErr: ErrBudgetExceeded,
+		},
+		{
+			name:   "quadratic cost fits the budget",
+			room:   rooms.Room{VoteBudget: 10, QuadraticVoting: true},
+			poll:   openPoll,
+			spent:  voterepository.Spent{Points: 1, QuadraticPoints: 1},
+			kind:   rooms.VoteKindApprove,
+			points: 3,
+		},
+		{
+			name:    "quadratic cost exceeds the budget",
+			room:    rooms.Room{VoteBudget: 10, QuadraticVoting: true},
+			poll:    openPoll,
+			spent:   voterepository.Spent{Points: 2, QuadraticPoints: 4},
+			kind:    rooms.VoteKindApprove,
+			points:  3,
+			wantErr: ErrBudgetExceeded,
+		},
+		{
+			name:    "negative points",
+			room:    rooms.Room{VoteBudget: 5},
+			poll:    openPoll,
+			kind:    rooms.VoteKindApprove,
+			points:  -1,
+			wantErr: ErrInvalidPoints,
+		},
+		{
+			name:    "closed poll",
+			poll:    rooms.Poll{ID: openPoll.ID, Status: rooms.PollStatusClosed},
+			kind:    rooms.VoteKindApprove,
+			wantErr: ErrVotingClosed,
+		},
+		{
+			name:    "game outside the runoff",
+			poll:    rooms.Poll{ID: openPoll.ID, Status: rooms.PollStatusOpen, RunoffOf: uuid.New().String(), Candidates: []string{uuid.New().String()}},
+			kind:    rooms.VoteKindApprove,
+			wantErr: ErrNotInRunoff,
+		},
+		{
+			name:        "game of another room or archived",
+			poll:        openPoll,
+			missingGame: true,
+			kind:        rooms.VoteKindApprove,
+			wantErr:     ErrGameNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepo{spent: tt.spent, missingGame: tt.missingGame}
+			s := NewService(repo, fakeRooms{room: tt.room}, fakePolls{poll: tt.poll})
+
+			vote, err := s.Add(context.Background(), rooms.Vote{
+				ID:     uuid.New().String(),
+				RoomID: uuid.New().String(),
+				GameID: gameID,
+				UserID: uuid.New().String(),
+				Kind:   tt.kind,
+				Points: tt.points,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.added) != 0 {
+					t.Errorf("vote was saved despite %v", tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(repo.added) != 1 {
+				t.Fatalf("saved %d votes, want 1", len(repo.added))
+			}
+			if vote.PollID != tt.poll.ID {
+				t.Errorf("poll = %q, want the current poll %q", vote.PollID, tt.poll.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+-- ROOMS: стратегия выбора игры по умолчанию
+ALTER TABLE rooms
+  ADD COLUMN pick_strategy VARCHAR(20) NOT NULL DEFAULT 'weighted';
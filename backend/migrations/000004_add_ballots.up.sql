@@ -0,0 +1,9 @@
+-- BALLOTS: ранжированные бюллетени (игры в порядке предпочтения)
+CREATE TABLE ballots (
+  id         UUID PRIMARY KEY,
+  room_id    UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+  user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  rankings   UUID[] NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE(room_id, user_id)  -- один бюллетень на участника комнаты
+);
@@ -0,0 +1,5 @@
+ALTER TABLE rooms
+  DROP COLUMN IF EXISTS veto_limit;
+
+ALTER TABLE votes
+  DROP COLUMN IF EXISTS kind;
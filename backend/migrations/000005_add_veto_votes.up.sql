@@ -0,0 +1,7 @@
+-- VOTES: вид голоса (одобрение или вето)
+ALTER TABLE votes
+  ADD COLUMN kind VARCHAR(10) NOT NULL DEFAULT 'approve' CHECK (kind IN ('approve', 'veto'));
+
+-- ROOMS: сколько вето доступно одному участнику
+ALTER TABLE rooms
+  ADD COLUMN veto_limit INT NOT NULL DEFAULT 1 CHECK (veto_limit >= 0);
@@ -0,0 +1,6 @@
+ALTER TABLE rooms
+  DROP COLUMN IF EXISTS quadratic_voting,
+  DROP COLUMN IF EXISTS vote_budget;
+
+ALTER TABLE votes
+  DROP COLUMN IF EXISTS points;
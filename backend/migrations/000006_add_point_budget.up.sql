@@ -0,0 +1,8 @@
+-- VOTES: число очков, отданных игре
+ALTER TABLE votes
+  ADD COLUMN points INT NOT NULL DEFAULT 1 CHECK (points > 0);
+
+-- ROOMS: бюджет очков на участника (0 - один голос на игру) и квадратичная стоимость
+ALTER TABLE rooms
+  ADD COLUMN vote_budget INT NOT NULL DEFAULT 0 CHECK (vote_budget >= 0),
+  ADD COLUMN quadratic_voting BOOLEAN NOT NULL DEFAULT FALSE;
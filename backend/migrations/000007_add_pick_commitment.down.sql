@@ -0,0 +1,5 @@
+ALTER TABLE random_results
+  DROP COLUMN IF EXISTS snapshot,
+  DROP COLUMN IF EXISTS commitment,
+  DROP COLUMN IF EXISTS seed,
+  DROP COLUMN IF EXISTS strategy;
@@ -0,0 +1,6 @@
+-- RANDOM_RESULTS: данные для проверки розыгрыша (commit-reveal)
+ALTER TABLE random_results
+  ADD COLUMN strategy VARCHAR(20) NOT NULL DEFAULT '',
+  ADD COLUMN seed TEXT NOT NULL DEFAULT '',
+  ADD COLUMN commitment TEXT NOT NULL DEFAULT '',
+  ADD COLUMN snapshot JSONB NOT NULL DEFAULT '{}'::JSONB;
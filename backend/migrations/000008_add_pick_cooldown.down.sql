@@ -0,0 +1,3 @@
+ALTER TABLE rooms
+  DROP COLUMN IF EXISTS cooldown_days,
+  DROP COLUMN IF EXISTS cooldown_results;
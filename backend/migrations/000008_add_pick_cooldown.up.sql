@@ -0,0 +1,4 @@
+-- ROOMS: недавно выбранные игры не участвуют в розыгрыше
+ALTER TABLE rooms
+  ADD COLUMN cooldown_results INT NOT NULL DEFAULT 0 CHECK (cooldown_results >= 0),
+  ADD COLUMN cooldown_days INT NOT NULL DEFAULT 0 CHECK (cooldown_days >= 0);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bracket_votes;
+DROP TABLE IF EXISTS bracket_matches;
+DROP TABLE IF EXISTS brackets;
@@ -0,0 +1,37 @@
+-- BRACKETS: турнир на выбывание между играми комнаты
+CREATE TABLE brackets (
+  id             UUID PRIMARY KEY,
+  room_id        UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+  status         VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'finished', 'cancelled')),
+  match_seconds  INT NOT NULL CHECK (match_seconds > 0),
+  winner_game_id UUID,
+  created_by     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at     TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- не больше одного активного турнира в комнате
+CREATE UNIQUE INDEX brackets_active_room_idx ON brackets(room_id) WHERE status = 'active';
+
+-- BRACKET MATCHES: пара игр; game_b = NULL означает, что game_a проходит дальше без матча
+CREATE TABLE bracket_matches (
+  id             UUID PRIMARY KEY,
+  bracket_id     UUID NOT NULL REFERENCES brackets(id) ON DELETE CASCADE,
+  round          INT NOT NULL,
+  position       INT NOT NULL,
+  game_a         UUID NOT NULL,
+  game_b         UUID,
+  status         VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'open', 'closed')),
+  winner_game_id UUID,
+  opened_at      TIMESTAMPTZ,
+  closes_at      TIMESTAMPTZ,
+  UNIQUE(bracket_id, round, position)
+);
+
+-- BRACKET VOTES: голос участника в матче
+CREATE TABLE bracket_votes (
+  match_id   UUID NOT NULL REFERENCES bracket_matches(id) ON DELETE CASCADE,
+  user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  game_id    UUID NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (match_id, user_id)
+);
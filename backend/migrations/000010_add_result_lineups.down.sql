@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS random_results_batch_idx;
+
+ALTER TABLE random_results
+  DROP COLUMN IF EXISTS position,
+  DROP COLUMN IF EXISTS batch_id;
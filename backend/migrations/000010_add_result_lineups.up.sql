@@ -0,0 +1,11 @@
+-- RANDOM_RESULTS: несколько игр одного выбора образуют подборку на вечер
+ALTER TABLE random_results
+  ADD COLUMN batch_id UUID,
+  ADD COLUMN position INT NOT NULL DEFAULT 0 CHECK (position >= 0);
+
+UPDATE random_results SET batch_id = id;
+
+ALTER TABLE random_results
+  ALTER COLUMN batch_id SET NOT NULL;
+
+CREATE INDEX random_results_batch_idx ON random_results (room_id, batch_id, position);
@@ -0,0 +1,6 @@
+ALTER TABLE rooms
+  DROP COLUMN IF EXISTS ready_quorum,
+  DROP COLUMN IF EXISTS auto_pick;
+
+ALTER TABLE room_participants
+  DROP COLUMN IF EXISTS ready;
@@ -0,0 +1,8 @@
+-- ROOM_PARTICIPANTS: готовность участника к выбору игры
+ALTER TABLE room_participants
+  ADD COLUMN ready BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- ROOMS: автоматический выбор, когда готовы все участники или кворум
+ALTER TABLE rooms
+  ADD COLUMN auto_pick BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN ready_quorum INT NOT NULL DEFAULT 0 CHECK (ready_quorum >= 0);
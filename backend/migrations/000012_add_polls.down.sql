@@ -0,0 +1,23 @@
+ALTER TABLE random_results
+  DROP COLUMN IF EXISTS poll_id;
+
+-- ВНИМАНИЕ: откат теряет данные. Голоса прошлых раундов не укладываются в прежнее ограничение
+-- уникальности (room_id, game_id, user_id), поэтому в каждой комнате остаются только голоса
+-- последнего раунда, а голоса прошлых раундов удаляются безвозвратно. Результаты выбора
+-- при этом теряют привязку к раунду. Перед откатом сохраните копию таблицы votes.
+DELETE FROM votes v
+USING polls p
+WHERE p.id = v.poll_id
+  AND p.id <> (
+    SELECT l.id FROM polls l
+    WHERE l.room_id = p.room_id
+    ORDER BY l.created_at DESC
+    LIMIT 1
+  );
+
+ALTER TABLE votes
+  DROP CONSTRAINT IF EXISTS votes_poll_id_game_id_user_id_key,
+  DROP COLUMN IF EXISTS poll_id,
+  ADD CONSTRAINT votes_room_id_game_id_user_id_key UNIQUE (room_id, game_id, user_id);
+
+DROP TABLE IF EXISTS polls;
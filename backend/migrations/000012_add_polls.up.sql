@@ -0,0 +1,42 @@
+-- POLLS: раунд голосования в комнате
+CREATE TABLE polls (
+  id         UUID PRIMARY KEY,
+  room_id    UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+  status     VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed', 'picked')),
+  opened_by  UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  closed_at  TIMESTAMPTZ
+);
+
+-- не больше одного открытого раунда в комнате
+CREATE UNIQUE INDEX polls_open_room_idx ON polls(room_id) WHERE status = 'open';
+CREATE INDEX polls_room_idx ON polls(room_id, created_at);
+
+-- существующие голоса и результаты попадают в первый раунд комнаты
+INSERT INTO polls (id, room_id, status, opened_by)
+SELECT gen_random_uuid(), id, 'open', owner_id
+FROM rooms;
+
+-- VOTES: голос относится к раунду, в одном раунде участник голосует за игру один раз
+ALTER TABLE votes
+  ADD COLUMN poll_id UUID REFERENCES polls(id) ON DELETE CASCADE;
+
+UPDATE votes v SET poll_id = p.id
+FROM polls p
+WHERE p.room_id = v.room_id;
+
+ALTER TABLE votes
+  ALTER COLUMN poll_id SET NOT NULL,
+  DROP CONSTRAINT IF EXISTS votes_room_id_game_id_user_id_key,
+  ADD CONSTRAINT votes_poll_id_game_id_user_id_key UNIQUE (poll_id, game_id, user_id);
+
+-- RANDOM_RESULTS: результат выбора относится к раунду
+ALTER TABLE random_results
+  ADD COLUMN poll_id UUID REFERENCES polls(id) ON DELETE CASCADE;
+
+UPDATE random_results r SET poll_id = p.id
+FROM polls p
+WHERE p.room_id = r.room_id;
+
+ALTER TABLE random_results
+  ALTER COLUMN poll_id SET NOT NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS polls_deadline_idx;
+
+ALTER TABLE polls
+  DROP COLUMN IF EXISTS deadline;
@@ -0,0 +1,5 @@
+-- POLLS: срок голосования, по истечении которого сервер сам выбирает игру
+ALTER TABLE polls
+  ADD COLUMN deadline TIMESTAMPTZ;
+
+CREATE INDEX polls_deadline_idx ON polls(deadline) WHERE status = 'open' AND deadline IS NOT NULL;
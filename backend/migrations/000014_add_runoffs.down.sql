@@ -0,0 +1,6 @@
+ALTER TABLE polls
+  DROP COLUMN IF EXISTS candidates,
+  DROP COLUMN IF EXISTS runoff_of;
+
+ALTER TABLE rooms
+  DROP COLUMN IF EXISTS runoff_seconds;
@@ -0,0 +1,8 @@
+-- ROOMS: длительность дополнительного раунда при ничьей; 0 - ничья решается случайно
+ALTER TABLE rooms
+  ADD COLUMN runoff_seconds INT NOT NULL DEFAULT 0 CHECK (runoff_seconds >= 0);
+
+-- POLLS: дополнительный раунд между играми, разделившими первое место в раунде runoff_of
+ALTER TABLE polls
+  ADD COLUMN runoff_of UUID REFERENCES polls(id) ON DELETE CASCADE,
+  ADD COLUMN candidates UUID[] NOT NULL DEFAULT '{}';
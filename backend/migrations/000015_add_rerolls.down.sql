@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS random_results_rerolled_idx;
+
+ALTER TABLE random_results
+  DROP COLUMN IF EXISTS reroll_reason,
+  DROP COLUMN IF EXISTS rerolled_at,
+  DROP COLUMN IF EXISTS rerolled_by,
+  DROP COLUMN IF EXISTS reroll_of;
+
+ALTER TABLE rooms
+  DROP COLUMN IF EXISTS reroll_limit;
@@ -0,0 +1,13 @@
+-- ROOMS: сколько раз за сутки можно перевыбрать игру; 0 - перевыбор запрещён
+ALTER TABLE rooms
+  ADD COLUMN reroll_limit INT NOT NULL DEFAULT 1 CHECK (reroll_limit >= 0);
+
+-- RANDOM_RESULTS: перевыбор отменяет результат и фиксирует, кто и почему его отменил;
+-- новый результат ссылается на отменённый
+ALTER TABLE random_results
+  ADD COLUMN reroll_of UUID REFERENCES random_results(id) ON DELETE SET NULL,
+  ADD COLUMN rerolled_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  ADD COLUMN rerolled_at TIMESTAMPTZ,
+  ADD COLUMN reroll_reason TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX random_results_rerolled_idx ON random_results(room_id, rerolled_at) WHERE rerolled_at IS NOT NULL;
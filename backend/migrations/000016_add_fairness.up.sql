@@ -0,0 +1,4 @@
+-- ROOMS: режим справедливости - голоса участников, чьи игры давно не выигрывали,
+-- весят больше, а голоса недавних победителей - меньше
+ALTER TABLE rooms
+  ADD COLUMN fairness BOOLEAN NOT NULL DEFAULT FALSE;
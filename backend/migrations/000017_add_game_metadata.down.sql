@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS games_tags_idx;
+
+ALTER TABLE games
+  DROP CONSTRAINT IF EXISTS games_players_range,
+  DROP COLUMN IF EXISTS notes,
+  DROP COLUMN IF EXISTS link,
+  DROP COLUMN IF EXISTS tags,
+  DROP COLUMN IF EXISTS duration_minutes,
+  DROP COLUMN IF EXISTS max_players,
+  DROP COLUMN IF EXISTS min_players;
@@ -0,0 +1,11 @@
+-- GAMES: описание игры для выбора с учётом состава и времени; 0 - значение не указано
+ALTER TABLE games
+  ADD COLUMN min_players INT NOT NULL DEFAULT 0 CHECK (min_players >= 0),
+  ADD COLUMN max_players INT NOT NULL DEFAULT 0 CHECK (max_players >= 0),
+  ADD COLUMN duration_minutes INT NOT NULL DEFAULT 0 CHECK (duration_minutes >= 0),
+  ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}',
+  ADD COLUMN link TEXT NOT NULL DEFAULT '',
+  ADD COLUMN notes TEXT NOT NULL DEFAULT '',
+  ADD CONSTRAINT games_players_range CHECK (min_players = 0 OR max_players = 0 OR min_players <= max_players);
+
+CREATE INDEX games_tags_idx ON games USING GIN (tags);
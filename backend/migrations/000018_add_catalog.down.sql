@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS games_catalog_idx;
+
+ALTER TABLE games
+  DROP COLUMN IF EXISTS catalog_id;
+
+DROP TABLE IF EXISTS catalog_games;
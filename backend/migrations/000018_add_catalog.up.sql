@@ -0,0 +1,35 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- CATALOG_GAMES: общий каталог игр; игры комнат ссылаются на него
+CREATE TABLE catalog_games (
+  id               UUID PRIMARY KEY,
+  title            TEXT NOT NULL,
+  min_players      INT NOT NULL DEFAULT 0 CHECK (min_players >= 0),
+  max_players      INT NOT NULL DEFAULT 0 CHECK (max_players >= 0),
+  duration_minutes INT NOT NULL DEFAULT 0 CHECK (duration_minutes >= 0),
+  tags             TEXT[] NOT NULL DEFAULT '{}',
+  link             TEXT NOT NULL DEFAULT '',
+  created_by       UUID REFERENCES users(id) ON DELETE SET NULL,
+  created_at       TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- одна запись каталога на название без учёта регистра; индексы для поиска по префиксу и триграммам
+CREATE UNIQUE INDEX catalog_games_title_key ON catalog_games(lower(title));
+CREATE INDEX catalog_games_title_prefix_idx ON catalog_games(lower(title) text_pattern_ops);
+CREATE INDEX catalog_games_title_trgm_idx ON catalog_games USING GIN (title gin_trgm_ops);
+
+-- существующие игры комнат попадают в каталог по названию
+INSERT INTO catalog_games (id, title)
+SELECT gen_random_uuid(), MIN(title)
+FROM games
+GROUP BY lower(title);
+
+-- GAMES: игра комнаты ссылается на запись каталога, её поля - описание игры в этой комнате
+ALTER TABLE games
+  ADD COLUMN catalog_id UUID REFERENCES catalog_games(id) ON DELETE SET NULL;
+
+UPDATE games g SET catalog_id = c.id
+FROM catalog_games c
+WHERE lower(c.title) = lower(g.title);
+
+CREATE INDEX games_catalog_idx ON games(catalog_id);
@@ -0,0 +1,19 @@
+-- LIBRARY_GAMES: личная библиотека игр пользователя, из которой он добавляет игры в свои комнаты
+CREATE TABLE library_games (
+  id               UUID PRIMARY KEY,
+  user_id          UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  catalog_id       UUID REFERENCES catalog_games(id) ON DELETE SET NULL,
+  title            TEXT NOT NULL,
+  min_players      INT NOT NULL DEFAULT 0 CHECK (min_players >= 0),
+  max_players      INT NOT NULL DEFAULT 0 CHECK (max_players >= 0),
+  duration_minutes INT NOT NULL DEFAULT 0 CHECK (duration_minutes >= 0),
+  tags             TEXT[] NOT NULL DEFAULT '{}',
+  link             TEXT NOT NULL DEFAULT '',
+  notes            TEXT NOT NULL DEFAULT '',
+  created_at       TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT library_games_players_range CHECK (min_players = 0 OR max_players = 0 OR min_players <= max_players)
+);
+
+-- игра каталога встречается в библиотеке пользователя один раз; по каталогу ищутся владельцы игр комнаты
+CREATE UNIQUE INDEX library_games_user_catalog_key ON library_games(user_id, catalog_id);
+CREATE INDEX library_games_catalog_idx ON library_games(catalog_id);
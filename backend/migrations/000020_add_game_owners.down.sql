@@ -0,0 +1,5 @@
+ALTER TABLE room_participants
+  DROP COLUMN IF EXISTS present;
+
+ALTER TABLE games
+  DROP COLUMN IF EXISTS brought_by;
@@ -0,0 +1,7 @@
+-- GAMES: участник, который приносит игру
+ALTER TABLE games
+  ADD COLUMN brought_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- ROOM_PARTICIPANTS: участник отмечен присутствующим на вечере
+ALTER TABLE room_participants
+  ADD COLUMN present BOOLEAN NOT NULL DEFAULT FALSE;
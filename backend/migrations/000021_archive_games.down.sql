@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS games_room_active_idx;
+
+ALTER TABLE games
+  DROP COLUMN IF EXISTS archived_at;
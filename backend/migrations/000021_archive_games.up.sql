@@ -0,0 +1,5 @@
+-- GAMES: удалённые игры архивируются, чтобы история выборов продолжала на них ссылаться
+ALTER TABLE games
+  ADD COLUMN archived_at TIMESTAMPTZ;
+
+CREATE INDEX games_room_active_idx ON games(room_id) WHERE archived_at IS NULL;
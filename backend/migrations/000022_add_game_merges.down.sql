@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS games_merged_into_idx;
+
+ALTER TABLE games
+  DROP COLUMN IF EXISTS merged_into;
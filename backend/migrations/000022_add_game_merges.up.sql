@@ -0,0 +1,6 @@
+-- GAMES: слитая игра не удаляется, а уходит в архив со ссылкой на игру, в которую её слили,
+-- чтобы сохранённые снимки розыгрышей оставались неизменными и проверялись по исходным ID
+ALTER TABLE games
+  ADD COLUMN merged_into UUID REFERENCES games(id) ON DELETE SET NULL;
+
+CREATE INDEX games_merged_into_idx ON games(merged_into) WHERE merged_into IS NOT NULL;
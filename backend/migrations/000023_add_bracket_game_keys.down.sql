@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS bracket_matches_open_idx;
+
+ALTER TABLE bracket_votes
+  DROP CONSTRAINT IF EXISTS bracket_votes_game_id_fkey;
+
+ALTER TABLE bracket_matches
+  DROP CONSTRAINT IF EXISTS bracket_matches_winner_game_id_fkey,
+  DROP CONSTRAINT IF EXISTS bracket_matches_game_b_fkey,
+  DROP CONSTRAINT IF EXISTS bracket_matches_game_a_fkey;
+
+ALTER TABLE brackets
+  DROP CONSTRAINT IF EXISTS brackets_winner_game_id_fkey;
@@ -0,0 +1,16 @@
+-- BRACKETS: ID игр турнира ссылаются на игры комнаты. Игры удаляются только вместе с комнатой,
+-- поэтому каскад срабатывает одновременно с удалением турниров комнаты.
+-- NOT VALID: турниры, сыгранные до архивирования игр, могут ссылаться на уже удалённые игры
+ALTER TABLE brackets
+  ADD CONSTRAINT brackets_winner_game_id_fkey FOREIGN KEY (winner_game_id) REFERENCES games(id) ON DELETE CASCADE NOT VALID;
+
+ALTER TABLE bracket_matches
+  ADD CONSTRAINT bracket_matches_game_a_fkey FOREIGN KEY (game_a) REFERENCES games(id) ON DELETE CASCADE NOT VALID,
+  ADD CONSTRAINT bracket_matches_game_b_fkey FOREIGN KEY (game_b) REFERENCES games(id) ON DELETE CASCADE NOT VALID,
+  ADD CONSTRAINT bracket_matches_winner_game_id_fkey FOREIGN KEY (winner_game_id) REFERENCES games(id) ON DELETE CASCADE NOT VALID;
+
+ALTER TABLE bracket_votes
+  ADD CONSTRAINT bracket_votes_game_id_fkey FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE NOT VALID;
+
+-- по открытым матчам таймеры турниров восстанавливаются при запуске сервера
+CREATE INDEX bracket_matches_open_idx ON bracket_matches(closes_at) WHERE status = 'open';
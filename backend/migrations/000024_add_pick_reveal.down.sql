@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS random_results_unrevealed_idx;
+
+ALTER TABLE random_results
+  DROP COLUMN IF EXISTS revealed_at,
+  DROP COLUMN IF EXISTS lands_at;
@@ -0,0 +1,10 @@
+-- RANDOM_RESULTS: церемония выбора хранится в базе. Результат не раскрывается до lands_at,
+-- а после перезапуска сервера объявление восстанавливается по результатам без revealed_at
+ALTER TABLE random_results
+  ADD COLUMN lands_at TIMESTAMPTZ,
+  ADD COLUMN revealed_at TIMESTAMPTZ;
+
+-- результаты, выбранные раньше, уже объявлены
+UPDATE random_results SET lands_at = created_at, revealed_at = created_at;
+
+CREATE INDEX random_results_unrevealed_idx ON random_results(room_id, lands_at) WHERE revealed_at IS NULL;
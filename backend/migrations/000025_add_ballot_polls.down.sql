@@ -0,0 +1,17 @@
+-- ВНИМАНИЕ: откат теряет данные. Прежнее ограничение допускает один бюллетень на участника
+-- комнаты, поэтому у каждого участника остаётся только бюллетень последнего раунда,
+-- а бюллетени прошлых раундов удаляются безвозвратно.
+DELETE FROM ballots b
+USING polls p
+WHERE p.id = b.poll_id
+  AND EXISTS (
+    SELECT 1 FROM ballots n
+    JOIN polls np ON np.id = n.poll_id
+    WHERE n.room_id = b.room_id AND n.user_id = b.user_id
+      AND np.created_at > p.created_at
+  );
+
+ALTER TABLE ballots
+  DROP CONSTRAINT IF EXISTS ballots_poll_id_user_id_key,
+  DROP COLUMN IF EXISTS poll_id,
+  ADD CONSTRAINT ballots_room_id_user_id_key UNIQUE (room_id, user_id);
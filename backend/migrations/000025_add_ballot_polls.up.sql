@@ -0,0 +1,23 @@
+-- BALLOTS: бюллетень относится к раунду, в одном раунде у участника один бюллетень
+ALTER TABLE ballots
+  ADD COLUMN poll_id UUID REFERENCES polls(id) ON DELETE CASCADE;
+
+-- комнатам с бюллетенями, где ещё не открывался раунд, открывается первый раунд
+INSERT INTO polls (id, room_id, status, opened_by)
+SELECT gen_random_uuid(), r.id, 'open', r.owner_id
+FROM rooms r
+WHERE EXISTS (SELECT 1 FROM ballots b WHERE b.room_id = r.id)
+  AND NOT EXISTS (SELECT 1 FROM polls p WHERE p.room_id = r.id);
+
+-- существующие бюллетени попадают в текущий (последний) раунд комнаты
+UPDATE ballots b SET poll_id = (
+  SELECT p.id FROM polls p
+  WHERE p.room_id = b.room_id
+  ORDER BY p.created_at DESC
+  LIMIT 1
+);
+
+ALTER TABLE ballots
+  ALTER COLUMN poll_id SET NOT NULL,
+  DROP CONSTRAINT IF EXISTS ballots_room_id_user_id_key,
+  ADD CONSTRAINT ballots_poll_id_user_id_key UNIQUE (poll_id, user_id);
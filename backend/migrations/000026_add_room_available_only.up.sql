@@ -0,0 +1,3 @@
+-- ROOMS: выбор только из игр, которые приносят присутствующие участники
+ALTER TABLE rooms
+  ADD COLUMN available_only BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE random_results
+  DROP COLUMN IF EXISTS options;
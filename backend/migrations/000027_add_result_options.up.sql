@@ -0,0 +1,3 @@
+-- RANDOM_RESULTS: ограничения розыгрыша, с которыми проходит перевыбор результата
+ALTER TABLE random_results
+  ADD COLUMN options JSONB NOT NULL DEFAULT '{}'::JSONB;
@@ -112,6 +112,16 @@ export const apiClient = {
     });
   },
 
+  // POST запрос с телом как есть, например содержимым файла
+  postRaw<T>(endpoint: string, body: BodyInit, contentType: string, options?: RequestInit): Promise<T> {
+    return request<T>(endpoint, {
+      ...options,
+      method: 'POST',
+      headers: { 'Content-Type': contentType, ...options?.headers },
+      body,
+    });
+  },
+
   delete<T>(endpoint: string, options?: RequestInit): Promise<T> {
     return request<T>(endpoint, { ...options, method: 'DELETE' });
   },
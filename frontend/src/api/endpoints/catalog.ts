@@ -0,0 +1,16 @@
+import { apiClient } from '../client';
+import type { CatalogGame, CatalogGameDetails } from '../types';
+
+export const catalogApi = {
+  search(query = '', limit?: number): Promise<CatalogGame[]> {
+    const params = new URLSearchParams();
+    if (query) params.set('q', query);
+    if (limit) params.set('limit', String(limit));
+    const search = params.toString();
+    return apiClient.get<CatalogGame[]>(search ? `/catalog?${search}` : '/catalog');
+  },
+
+  get(catalogId: string): Promise<CatalogGameDetails> {
+    return apiClient.get<CatalogGameDetails>(`/catalog/${catalogId}`);
+  },
+};
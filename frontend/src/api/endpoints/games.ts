@@ -1,16 +1,62 @@
 import { apiClient } from '../client';
-import type { Game, GamesResponse, CreateGameRequest } from '../types';
+import type { Game, GamesResponse, CreateGameRequest, UpdateGameRequest, AddCatalogGameRequest, AddLibraryGameRequest, GameOwners, MergeGamesRequest, ImportFormat, ImportGamesResponse } from '../types';
 
 export const gamesApi = {
   add(roomId: string, data: CreateGameRequest): Promise<Game> {
     return apiClient.post<Game>(`/rooms/${roomId}/games`, data);
   },
 
+  addFromCatalog(roomId: string, catalogId: string, force = false): Promise<Game> {
+    const data: AddCatalogGameRequest = { catalog_id: catalogId, force };
+    return apiClient.post<Game>(`/rooms/${roomId}/games`, data);
+  },
+
+  addFromLibrary(roomId: string, libraryGameId: string, force = false): Promise<Game> {
+    const data: AddLibraryGameRequest = { library_game_id: libraryGameId, force };
+    return apiClient.post<Game>(`/rooms/${roomId}/games`, data);
+  },
+
+  getOwners(roomId: string): Promise<GameOwners[]> {
+    return apiClient.get<GameOwners[]>(`/rooms/${roomId}/games/owners`);
+  },
+
+  import(roomId: string, format: ImportFormat, file: Blob): Promise<ImportGamesResponse> {
+    return apiClient.postRaw<ImportGamesResponse>(
+      `/rooms/${roomId}/games/import?format=${format}`,
+      file,
+      file.type || 'application/octet-stream'
+    );
+  },
+
+  // Экспорт в JSON; тот же массив можно передать в import с форматом 'json'
+  export(roomId: string): Promise<GamesResponse> {
+    return apiClient.get<GamesResponse>(`/rooms/${roomId}/games/export?format=json`);
+  },
+
   getAll(roomId: string): Promise<GamesResponse> {
     return apiClient.get<GamesResponse>(`/rooms/${roomId}/games`);
   },
 
+  update(roomId: string, gameId: string, data: UpdateGameRequest): Promise<Game> {
+    return apiClient.put<Game>(`/rooms/${roomId}/games/${gameId}`, data);
+  },
+
+  // Переносит голоса и историю игры gameId на игру into и убирает gameId из комнаты
+  merge(roomId: string, gameId: string, into: string): Promise<Game> {
+    const data: MergeGamesRequest = { into };
+    return apiClient.post<Game>(`/rooms/${roomId}/games/${gameId}/merge`, data);
+  },
+
+  // Убирает игру в архив; её можно вернуть через restore
   delete(roomId: string, gameId: string): Promise<void> {
     return apiClient.delete<void>(`/rooms/${roomId}/games/${gameId}`);
   },
+
+  getArchived(roomId: string): Promise<GamesResponse> {
+    return apiClient.get<GamesResponse>(`/rooms/${roomId}/games/archived`);
+  },
+
+  restore(roomId: string, gameId: string): Promise<Game> {
+    return apiClient.post<Game>(`/rooms/${roomId}/games/${gameId}/restore`);
+  },
 };
@@ -0,0 +1,20 @@
+import { apiClient } from '../client';
+import type { LibraryGame, CreateLibraryGameRequest, UpdateLibraryGameRequest } from '../types';
+
+export const libraryApi = {
+  getAll(): Promise<LibraryGame[]> {
+    return apiClient.get<LibraryGame[]>('/user/library');
+  },
+
+  add(data: CreateLibraryGameRequest): Promise<LibraryGame> {
+    return apiClient.post<LibraryGame>('/user/library', data);
+  },
+
+  update(libraryGameId: string, data: UpdateLibraryGameRequest): Promise<LibraryGame> {
+    return apiClient.put<LibraryGame>(`/user/library/${libraryGameId}`, data);
+  },
+
+  delete(libraryGameId: string): Promise<void> {
+    return apiClient.delete<void>(`/user/library/${libraryGameId}`);
+  },
+};
@@ -1,5 +1,5 @@
 import { apiClient } from '../client';
-import type { ParticipantsResponse, InviteParticipantRequest } from '../types';
+import type { ParticipantsResponse, InviteParticipantRequest, SetReadyRequest, SetReadyResponse, SetPresentRequest, Presence } from '../types';
 
 export const participantsApi = {
   invite(roomId: string, data: InviteParticipantRequest): Promise<void> {
@@ -13,4 +13,12 @@ export const participantsApi = {
   leave(roomId: string): Promise<void> {
     return apiClient.delete<void>(`/rooms/${roomId}/participants`);
   },
+
+  setReady(roomId: string, data: SetReadyRequest): Promise<SetReadyResponse> {
+    return apiClient.put<SetReadyResponse>(`/rooms/${roomId}/ready`, data);
+  },
+
+  setPresent(roomId: string, data: SetPresentRequest): Promise<Presence> {
+    return apiClient.put<Presence>(`/rooms/${roomId}/present`, data);
+  },
 };
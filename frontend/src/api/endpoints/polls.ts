@@ -0,0 +1,24 @@
+import { apiClient } from '../client';
+import type { Poll, SetDeadlineRequest } from '../types';
+
+export const pollsApi = {
+  open(roomId: string): Promise<Poll> {
+    return apiClient.post<Poll>(`/rooms/${roomId}/polls`);
+  },
+
+  getAll(roomId: string): Promise<Poll[]> {
+    return apiClient.get<Poll[]>(`/rooms/${roomId}/polls`);
+  },
+
+  getCurrent(roomId: string): Promise<Poll> {
+    return apiClient.get<Poll>(`/rooms/${roomId}/polls/current`);
+  },
+
+  close(roomId: string): Promise<Poll> {
+    return apiClient.post<Poll>(`/rooms/${roomId}/polls/current/close`);
+  },
+
+  setDeadline(roomId: string, data: SetDeadlineRequest): Promise<Poll> {
+    return apiClient.put<Poll>(`/rooms/${roomId}/polls/current/deadline`, data);
+  },
+};
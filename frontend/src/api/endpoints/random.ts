@@ -1,9 +1,24 @@
 import { apiClient } from '../client';
-import type { RandomResult, RandomHistoryResponse } from '../types';
+import type { RandomPick, RandomResult, RandomHistoryResponse, RandomVerification, RerollPick, RerollRequest, RandomOdds, PickConstraints } from '../types';
+
+function constraintsQuery(constraints: PickConstraints = {}): URLSearchParams {
+  const params = new URLSearchParams();
+  if (constraints.players) params.set('players', String(constraints.players));
+  if (constraints.max_minutes) params.set('max_minutes', String(constraints.max_minutes));
+  if (constraints.tags?.length) params.set('tags', constraints.tags.join(','));
+  if (constraints.exclude_tags?.length) params.set('exclude_tags', constraints.exclude_tags.join(','));
+  if (constraints.available_only) params.set('available_only', 'true');
+  return params;
+}
+
+function withQuery(path: string, params: URLSearchParams): string {
+  const query = params.toString();
+  return query ? `${path}?${query}` : path;
+}
 
 export const randomApi = {
-  generate(roomId: string): Promise<RandomResult> {
-    return apiClient.get<RandomResult>(`/rooms/${roomId}/random`);
+  generate(roomId: string, constraints?: PickConstraints): Promise<RandomPick> {
+    return apiClient.get<RandomPick>(withQuery(`/rooms/${roomId}/random`, constraintsQuery(constraints)));
   },
 
   getLast(roomId: string): Promise<RandomResult> {
@@ -13,4 +28,18 @@ export const randomApi = {
   getHistory(roomId: string): Promise<RandomHistoryResponse> {
     return apiClient.get<RandomHistoryResponse>(`/rooms/${roomId}/random/history`);
   },
+
+  verify(roomId: string, resultId: string): Promise<RandomVerification> {
+    return apiClient.get<RandomVerification>(`/rooms/${roomId}/random/${resultId}/verify`);
+  },
+
+  getOdds(roomId: string, strategy?: string, constraints?: PickConstraints): Promise<RandomOdds> {
+    const params = constraintsQuery(constraints);
+    if (strategy) params.set('strategy', strategy);
+    return apiClient.get<RandomOdds>(withQuery(`/rooms/${roomId}/random/odds`, params));
+  },
+
+  reroll(roomId: string, resultId: string, data: RerollRequest): Promise<RerollPick> {
+    return apiClient.post<RerollPick>(`/rooms/${roomId}/random/${resultId}/reroll`, data);
+  },
 };
@@ -6,8 +6,9 @@ export const votesApi = {
     return apiClient.post<Vote>(`/rooms/${roomId}/votes`, data);
   },
 
-  getAll(roomId: string): Promise<VotesResponse> {
-    return apiClient.get<VotesResponse>(`/rooms/${roomId}/votes`);
+  getAll(roomId: string, pollId?: string): Promise<VotesResponse> {
+    const query = pollId ? `?poll_id=${pollId}` : '';
+    return apiClient.get<VotesResponse>(`/rooms/${roomId}/votes${query}`);
   },
 
   delete(roomId: string, voteId: string): Promise<void> {
@@ -12,8 +12,11 @@ export { authApi } from './endpoints/auth';
 export { userApi } from './endpoints/user';
 export { roomsApi } from './endpoints/rooms';
 export { gamesApi } from './endpoints/games';
+export { catalogApi } from './endpoints/catalog';
+export { libraryApi } from './endpoints/library';
 export { participantsApi } from './endpoints/participants';
 export { votesApi } from './endpoints/votes';
+export { pollsApi } from './endpoints/polls';
 export { randomApi } from './endpoints/random';
 
 // WebSocket
@@ -48,6 +48,16 @@ export interface Game {
   id: string;
   room_id: string;
   title: string;
+  min_players: number;
+  max_players: number;
+  duration_minutes: number;
+  tags: string[];
+  link: string;
+  notes: string;
+  catalog_id: string;
+  brought_by: string;
+  // Нулевое время у игр не из архива
+  archived_at?: string;
   created_at?: string;
 }
 
@@ -55,14 +65,148 @@ export type GamesResponse = Game[];
 
 export interface CreateGameRequest {
   title: string;
+  min_players?: number;
+  max_players?: number;
+  duration_minutes?: number;
+  tags?: string[];
+  link?: string;
+  notes?: string;
+  brought_by?: string;
+  force?: boolean;
+}
+
+export type UpdateGameRequest = Partial<Omit<CreateGameRequest, 'force'>>;
+
+// Тело ответа 409 при добавлении игры: похожие игры комнаты
+export interface DuplicateGamesError {
+  error: string;
+  candidates: Game[];
+}
+
+export interface MergeGamesRequest {
+  into: string;
+}
+
+export type ImportFormat = 'bgg' | 'steam' | 'csv' | 'json';
+
+export interface ImportSkipped {
+  row: number;
+  title: string;
+  reason: string;
+}
+
+export interface ImportRowError {
+  row: number;
+  title: string;
+  error: string;
+}
+
+// Тело ответа 422 при импорте: ошибки всех некорректных игр файла
+export interface ImportGamesError {
+  error: string;
+  rows: ImportRowError[];
+}
+
+export interface ImportGamesResponse {
+  added: Game[];
+  skipped: ImportSkipped[];
+}
+
+export interface AddCatalogGameRequest {
+  catalog_id: string;
+  force?: boolean;
+}
+
+export interface AddLibraryGameRequest {
+  library_game_id: string;
+  force?: boolean;
+}
+
+// Библиотека игр пользователя
+export interface LibraryGame {
+  id: string;
+  user_id: string;
+  catalog_id: string;
+  title: string;
+  min_players: number;
+  max_players: number;
+  duration_minutes: number;
+  tags: string[];
+  link: string;
+  notes: string;
+  created_at: string;
+}
+
+export type CreateLibraryGameRequest = Omit<CreateGameRequest, 'force' | 'brought_by'>;
+
+export type UpdateLibraryGameRequest = Partial<CreateLibraryGameRequest>;
+
+// Участники комнаты, у которых игра комнаты есть в библиотеке
+export interface GameOwners {
+  game_id: string;
+  owners: (User & { created_at: string })[];
+}
+
+// Каталог игр
+export interface CatalogGame {
+  id: string;
+  title: string;
+  min_players: number;
+  max_players: number;
+  duration_minutes: number;
+  tags: string[];
+  link: string;
+  created_by: string;
+  created_at: string;
+}
+
+export interface CatalogStats {
+  rooms: number;
+  votes: number;
+  vetoes: number;
+  picks: number;
+  last_picked_at: string;
+}
+
+export interface CatalogGameDetails extends CatalogGame {
+  stats: CatalogStats;
 }
 
 // Участники
 export type ParticipantRole = 'owner' | 'member';
 
+export interface Readiness {
+  user_ids: string[];
+  ready: number;
+  total: number;
+  quorum: number;
+}
+
+// Присутствие участников: отметившиеся, подключённые по WebSocket и те и другие вместе
+export interface Presence {
+  present: string[];
+  connected: string[];
+  available: string[];
+}
+
 export interface ParticipantsResponse {
   users: User[];
   roles: ParticipantRole[];
+  readiness: Readiness;
+  presence: Presence;
+}
+
+export interface SetPresentRequest {
+  present?: boolean;
+}
+
+export interface SetReadyRequest {
+  ready?: boolean;
+}
+
+// Если отметка запустила автоматический выбор, pick содержит его результат
+export interface SetReadyResponse extends Readiness {
+  pick?: RandomPick;
 }
 
 export interface InviteParticipantRequest {
@@ -70,27 +214,193 @@ export interface InviteParticipantRequest {
 }
 
 // Голоса
+export type VoteKind = 'approve' | 'veto';
+
 export interface Vote {
   id: string;
   room_id: string;
+  poll_id: string;
   game_id: string;
   user_id: string;
+  kind: VoteKind;
+  points: number;
+}
+
+export interface VoteTally {
+  game_id: string;
+  voters: number;
+  points: number;
+  vetoes: number;
+}
+
+// Бюджет очков текущего пользователя в раунде; total = 0 - режим "один голос за игру"
+export interface VoteBudget {
+  total: number;
+  spent: number;
+  quadratic: boolean;
 }
 
 export interface VotesResponse {
   votes: Vote[];
+  tallies: VoteTally[];
+  budget: VoteBudget;
+  poll: Poll;
 }
 
 export interface AddVoteRequest {
   game_id: string;
+  kind?: VoteKind;
+  points?: number;
+}
+
+// Раунды голосования
+export type PollStatus = 'open' | 'closed' | 'picked';
+
+export interface Poll {
+  id: string;
+  room_id: string;
+  status: PollStatus;
+  opened_by: string;
+  created_at: string;
+  closed_at: string;
+  deadline: string;
+  runoff_of?: string;
+  candidates?: string[];
+}
+
+export interface SetDeadlineRequest {
+  deadline?: string;
 }
 
 // Случайный выбор
 // Возвращает game_id как UUID строку
 export type RandomResult = string;
 
-// История - массив game_id
-export type RandomHistoryResponse = string[];
+// Результат нового выбора
+export interface RunoffRound {
+  round: number;
+  tally: Record<string, number>;
+  exhausted: number;
+  eliminated?: string[];
+  winner?: string;
+}
+
+export interface LineupDraw {
+  id?: string;
+  position: number;
+  game_id: string;
+  rounds?: RunoffRound[];
+}
+
+// Начатая церемония: выпавшие игры приходят событием results.updated в lands_at
+export interface RandomPick {
+  id: string;
+  batch_id: string;
+  count: number;
+  strategy: string;
+  commitment: string;
+  started_at: string;
+  lands_at: string;
+}
+
+// Перевыбор результата
+export interface RerollRequest {
+  reason?: string;
+}
+
+export interface RerollPick extends RandomPick {
+  reroll_of: string;
+}
+
+// Ограничения на игры розыгрыша
+export interface PickConstraints {
+  players?: number;
+  max_minutes?: number;
+  tags?: string[];
+  exclude_tags?: string[];
+  available_only?: boolean;
+}
+
+// Шансы игр в розыгрыше прямо сейчас
+export type OddsExclusion =
+  | 'veto'
+  | 'cooldown'
+  | 'runoff'
+  | 'players'
+  | 'duration'
+  | 'tags'
+  | 'exclude_tags'
+  | 'available';
+
+export interface GameOdds {
+  game_id: string;
+  votes: number;
+  score: number;
+  vetoes: number;
+  weight: number;
+  probability: number;
+  excluded?: OddsExclusion;
+}
+
+// Множитель голосов участника в режиме справедливости
+export interface Fairness {
+  user_id: string;
+  wins: number;
+  multiplier: number;
+}
+
+export interface RandomOdds {
+  strategy: string;
+  poll_id: string;
+  estimated: boolean;
+  games: GameOdds[];
+  fairness?: Fairness[];
+}
+
+// Ничья вынесена в дополнительный раунд вместо выбора
+export interface RandomRunoff {
+  tied: string[];
+  runoff: Poll;
+}
+
+// Проверка розыгрыша по раскрытому зерну
+export interface DrawSnapshot {
+  candidates: { game_id: string; weight: number }[];
+  ballots?: string[][];
+}
+
+export interface RandomVerification {
+  result_id: string;
+  game_id: string;
+  recomputed_game_id: string;
+  strategy: string;
+  seed: string;
+  commitment: string;
+  commitment_valid: boolean;
+  valid: boolean;
+  position: number;
+  snapshot: DrawSnapshot;
+  rounds?: RunoffRound[];
+}
+
+// История - подборки игр, выбранных за один розыгрыш
+export interface Reroll {
+  by: string;
+  reason: string;
+  at: string;
+}
+
+export interface Lineup {
+  batch_id: string;
+  poll_id: string;
+  reroll_of?: string;
+  chosen_by: string;
+  strategy: string;
+  games: { result_id: string; position: number; game_id: string; title: string; archived: boolean; reroll?: Reroll }[];
+  created_at: string;
+}
+
+export type RandomHistoryResponse = Lineup[];
 
 // WebSocket
 export type WSEventType = 
@@ -98,10 +408,24 @@ export type WSEventType =
   | 'room.updated'
   | 'participant.added'
   | 'participant.left'
+  | 'participant.ready'
+  | 'participant.present'
   | 'game.added'
   | 'game.deleted'
+  | 'game.updated'
+  | 'game.restored'
   | 'vote.added'
   | 'vote.deleted'
+  | 'pick.started'
+  | 'pick.rerolled'
+  | 'pick.failed'
+  | 'odds.updated'
+  | 'poll.opened'
+  | 'poll.closed'
+  | 'poll.scheduled'
+  | 'poll.pick_failed'
+  | 'runoff.started'
+  | 'runoff.finished'
   | 'results.updated';
 
 export interface WSEvent<T = unknown> {
@@ -123,19 +447,109 @@ export interface WSParticipantPayload {
   user_id: string;
 }
 
+export interface WSParticipantReadyPayload {
+  user_id: string;
+  ready: boolean;
+  user_ids: string[];
+  count: number;
+  total: number;
+  quorum: number;
+}
+
+export interface WSParticipantPresentPayload {
+  user_id: string;
+  present: boolean;
+  available: string[];
+}
+
+export interface WSPollOpenedPayload {
+  id: string;
+  opened_by: string;
+}
+
+export interface WSPollClosedPayload {
+  id: string;
+  status: PollStatus;
+}
+
+export interface WSPollScheduledPayload {
+  id: string;
+  deadline: string;
+}
+
+// Сервер закрыл раунд по сроку, но не смог выбрать игру
+export interface WSPollPickFailedPayload {
+  poll_id: string;
+  error: string;
+}
+
+// Сервер не смог раскрыть результаты подборки в момент остановки колеса
+export interface WSPickFailedPayload {
+  batch_id: string;
+  error: string;
+}
+
+export interface WSRunoffStartedPayload {
+  poll_id: string;
+  runoff_of: string;
+  game_ids: string[];
+  deadline: string;
+}
+
+export interface WSRunoffFinishedPayload {
+  poll_id: string;
+  runoff_of: string;
+  id: string;
+  game_id: string;
+}
+
+export interface WSPickRerolledPayload {
+  result_id: string;
+  game_id: string;
+  rerolled_by: string;
+  reason: string;
+  id: string;
+  batch_id: string;
+}
+
+export type WSOddsUpdatedPayload = RandomOdds;
+
 export interface WSGamePayload {
   game_id: string;
   title?: string;
 }
 
+export type WSGameUpdatedPayload = Omit<Game, 'room_id' | 'created_at'>;
+
+export type WSGameRestoredPayload = WSGameUpdatedPayload;
+
 export interface WSVotePayload {
   vote_id: string;
   game_id: string;
   user_id: string;
+  kind?: VoteKind;
+  points?: number;
+  poll_id?: string;
+}
+
+export interface WSPickStartedPayload {
+  id: string;
+  batch_id: string;
+  count: number;
+  strategy: string;
+  commitment: string;
+  candidates: { game_id: string; weight: number }[];
+  started_at: string;
+  lands_at: string;
 }
 
 export interface WSResultsPayload {
+  id: string;
+  batch_id: string;
   game_id: string;
+  strategy: string;
+  rounds?: RunoffRound[];
+  lineup: LineupDraw[];
 }
 
 // Ошибки
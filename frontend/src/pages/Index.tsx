@@ -77,13 +77,16 @@ const Index = () => {
     return map;
   }, [votes, user]);
 
-  // Преобразование истории из game_id в объекты с названиями
+  // Преобразование подборок из истории в объекты с названиями игр
   const historyWithNames = useMemo(() => {
-    return randomHistory.map(gameId => {
-      const game = games.find(g => g.id === gameId);
+    return randomHistory.map(lineup => {
+      const names = lineup.games.map(item => {
+        const game = games.find(g => g.id === item.game_id);
+        return game?.title || 'Неизвестная игра';
+      });
       return {
-        gameId,
-        gameName: game?.title || 'Неизвестная игра',
+        gameId: lineup.games[0]?.game_id ?? '',
+        gameName: names.join(' → '),
       };
     });
   }, [randomHistory, games]);
@@ -160,8 +163,8 @@ const Index = () => {
 
   const handleRandomSelect = async () => {
     try {
-      const gameId = await getRandomMutation.mutateAsync();
-      const game = games.find(g => g.id === gameId);
+      const pick = await getRandomMutation.mutateAsync();
+      const game = games.find(g => g.id === pick.game_id);
       if (game) {
         setWinner(game.title);
         toast({